@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mcoluomo/pokedexcli/pokemon"
+)
+
+// GetAbility fetches an ability's effect and the Pokemon that can have it.
+// Errors wrap ErrNotFound, ErrRateLimited or ErrUpstream.
+func (c *Client) GetAbility(ctx context.Context, name string) (pokemon.Ability, error) {
+	url := fmt.Sprintf("%s/ability/%s", c.baseURL, name)
+	return fetch(ctx, c, url, c.parseAbilityResponse)
+}
+
+// parseAbilityResponse converts ability API response
+func (c *Client) parseAbilityResponse(data []byte) (pokemon.Ability, error) {
+	var apiResp struct {
+		Name          string `json:"name"`
+		EffectEntries []struct {
+			Effect      string        `json:"effect"`
+			ShortEffect string        `json:"short_effect"`
+			Language    namedResource `json:"language"`
+		} `json:"effect_entries"`
+		Pokemon []struct {
+			IsHidden bool          `json:"is_hidden"`
+			Pokemon  namedResource `json:"pokemon"`
+		} `json:"pokemon"`
+	}
+
+	if err := json.Unmarshal(data, &apiResp); err != nil {
+		return pokemon.Ability{}, fmt.Errorf("failed to parse ability response: %w", err)
+	}
+
+	a := pokemon.Ability{
+		Name:    apiResp.Name,
+		Holders: make([]pokemon.AbilityHolder, len(apiResp.Pokemon)),
+	}
+
+	// Use the English entry
+	for _, entry := range apiResp.EffectEntries {
+		if entry.Language.Name == "en" {
+			a.Effect = cleanText(entry.Effect)
+			a.ShortEffect = cleanText(entry.ShortEffect)
+			break
+		}
+	}
+
+	for i, holder := range apiResp.Pokemon {
+		a.Holders[i] = pokemon.AbilityHolder{
+			Pokemon:  holder.Pokemon.Name,
+			IsHidden: holder.IsHidden,
+		}
+	}
+
+	return a, nil
+}
@@ -1,10 +1,11 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/mcoluomo/pokedexcli/cache"
@@ -12,102 +13,122 @@ import (
 	"github.com/mcoluomo/pokedexcli/pokemon"
 )
 
+// DefaultBaseURL is the public PokeAPI root
+const DefaultBaseURL = "https://pokeapi.co/api/v2"
+
 // Client handles API communication
 type Client struct {
 	baseURL    string
 	httpClient *http.Client
-	cache      *cache.Cache
+	cache      cache.Store
+	inflight   *inflightGroup
+	retry      RetryPolicy
+	rateLimit  RateLimit
+	limiter    *rateLimiter
 	next       string
 	prev       string
+
+	memoryOnly bool // keep the default cache off disk
+}
+
+// Option configures optional Client behaviour
+type Option func(*Client)
+
+// WithStore makes the client cache responses in store instead of the
+// default in-memory cache
+func WithStore(store cache.Store) Option {
+	return func(c *Client) {
+		c.cache = store
+	}
+}
+
+// WithMemoryCache keeps the default cache in memory only, for runs whose
+// responses aren't worth keeping, such as against the fakeapi server
+func WithMemoryCache() Option {
+	return func(c *Client) {
+		c.memoryOnly = true
+	}
+}
+
+// WithBaseURL points the client at a different PokeAPI root, such as a
+// local mirror or the fakeapi server
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
 }
 
 // NewClient creates a new API client
-func NewClient() *Client {
-	return &Client{
-		baseURL: "https://pokeapi.co/api/v2",
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL: DefaultBaseURL,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
-		cache: cache.NewCache(15 * time.Minute),
-		next:  "https://pokeapi.co/api/v2/location-area/",
-		prev:  "",
+		inflight:  newInflightGroup(),
+		retry:     DefaultRetryPolicy(),
+		rateLimit: DefaultRateLimit(),
+		prev:      "",
 	}
-}
 
-// GetPokemon fetches a Pokemon and converts to domain model
-func (c *Client) GetPokemon(name string) (pokemon.Pokemon, error) {
-	url := fmt.Sprintf("%s/pokemon/%s", c.baseURL, name)
-
-	// Check cache first
-	if data, exists := c.cache.Get(url); exists {
-		return c.parsePokemonResponse(data)
+	for _, opt := range opts {
+		opt(c)
 	}
 
-	// Make HTTP request
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return pokemon.Pokemon{}, fmt.Errorf("failed to fetch Pokemon: %w", err)
-	}
-	defer resp.Body.Close()
+	c.limiter = newRateLimiter(c.rateLimit)
+
+	c.next = c.baseURL + "/location-area/"
 
-	if resp.StatusCode != http.StatusOK {
-		return pokemon.Pokemon{}, fmt.Errorf("API returned status %d for Pokemon %s", resp.StatusCode, name)
+	if c.cache == nil {
+		c.cache = newDefaultCache(!c.memoryOnly)
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return pokemon.Pokemon{}, fmt.Errorf("failed to read response: %w", err)
+	return c
+}
+
+// newDefaultCache bounds memory use and, if persist is set, keeps responses
+// between sessions when a user cache dir is available
+func newDefaultCache(persist bool) *cache.Cache {
+	cacheOpts := []cache.Option{
+		cache.WithMaxBytes(32 << 20),
+		cache.WithReaper(time.Minute),
+		cache.WithStaleRetention(24 * time.Hour),
+	}
+	if dir, err := cache.DefaultDir(); err == nil && persist {
+		cacheOpts = append(cacheOpts, cache.WithDir(dir))
 	}
 
-	// Cache the response
-	c.cache.Set(url, body)
+	return cache.NewCache(15*time.Minute, cacheOpts...)
+}
 
-	return c.parsePokemonResponse(body)
+// GetPokemon fetches a Pokemon and converts to domain model.
+// Errors wrap ErrNotFound, ErrRateLimited or ErrUpstream.
+func (c *Client) GetPokemon(ctx context.Context, name string) (pokemon.Pokemon, error) {
+	url := fmt.Sprintf("%s/pokemon/%s", c.baseURL, name)
+	return fetch(ctx, c, url, c.parsePokemonResponse)
 }
 
 // GetLocationAreas fetches location areas
-func (c *Client) GetLocationAreas() ([]location.LocationArea, error) {
+func (c *Client) GetLocationAreas(ctx context.Context) ([]location.LocationArea, error) {
 	url := c.next
 	if url == "" {
 		return nil, fmt.Errorf("no more locations available")
 	}
 
-	// Check cache first
-	if data, exists := c.cache.Get(url); exists {
-		return c.parseLocationResponse(data)
-	}
-
-	resp, err := c.httpClient.Get(url)
+	page, err := fetch(ctx, c, url, c.parseLocationResponse)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch locations: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	// Cache the response
-	c.cache.Set(url, body)
-
-	areas, err := c.parseLocationResponse(body)
-	if err != nil {
-		return nil, err
-	}
 
 	// Update pagination URLs
-	c.updatePagination(body)
+	c.next = page.next
+	c.prev = page.prev
 
-	return areas, nil
+	return page.areas, nil
 }
 
 // GetPreviousLocationAreas fetches previous page of location areas
-func (c *Client) GetPreviousLocationAreas() ([]location.LocationArea, error) {
+func (c *Client) GetPreviousLocationAreas(ctx context.Context) ([]location.LocationArea, error) {
 	if c.prev == "" {
 		return nil, fmt.Errorf("no previous locations available")
 	}
@@ -116,7 +137,7 @@ func (c *Client) GetPreviousLocationAreas() ([]location.LocationArea, error) {
 	originalNext := c.next
 	c.next = c.prev
 
-	areas, err := c.GetLocationAreas()
+	areas, err := c.GetLocationAreas(ctx)
 	if err != nil {
 		c.next = originalNext
 		return nil, err
@@ -125,34 +146,23 @@ func (c *Client) GetPreviousLocationAreas() ([]location.LocationArea, error) {
 	return areas, nil
 }
 
-// ExploreLocation fetches Pokemon in a specific location
-func (c *Client) ExploreLocation(areaName string) (location.LocationArea, error) {
+// ExploreLocation fetches Pokemon in a specific location.
+// Errors wrap ErrNotFound, ErrRateLimited or ErrUpstream.
+func (c *Client) ExploreLocation(ctx context.Context, areaName string) (location.LocationArea, error) {
 	url := fmt.Sprintf("%s/location-area/%s", c.baseURL, areaName)
+	return fetch(ctx, c, url, c.parseLocationAreaResponse)
+}
 
-	// Check cache first
-	if data, exists := c.cache.Get(url); exists {
-		return c.parseLocationAreaResponse(data)
-	}
-
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return location.LocationArea{}, fmt.Errorf("failed to explore location: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return location.LocationArea{}, fmt.Errorf("location %s not found", areaName)
-	}
+// Cache returns the response cache used by the client
+func (c *Client) Cache() cache.Store {
+	return c.cache
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return location.LocationArea{}, fmt.Errorf("failed to read response: %w", err)
+// Close releases resources held by the client
+func (c *Client) Close() {
+	if closer, ok := c.cache.(cache.Closer); ok {
+		closer.Close()
 	}
-
-	// Cache the response
-	c.cache.Set(url, body)
-
-	return c.parseLocationAreaResponse(body)
 }
 
 // HasNext checks if there are more locations to fetch
@@ -168,21 +178,31 @@ func (c *Client) HasPrev() bool {
 // parsePokemonResponse converts API response to Pokemon domain model
 func (c *Client) parsePokemonResponse(data []byte) (pokemon.Pokemon, error) {
 	var apiResp struct {
-		Name           string `json:"name"`
-		Height         int    `json:"height"`
-		Weight         int    `json:"weight"`
-		BaseExperience int    `json:"base_experience"`
+		Name           string        `json:"name"`
+		Height         int           `json:"height"`
+		Weight         int           `json:"weight"`
+		BaseExperience int           `json:"base_experience"`
+		Species        namedResource `json:"species"`
 		Types          []struct {
-			Type struct {
-				Name string `json:"name"`
-			} `json:"type"`
+			Type namedResource `json:"type"`
 		} `json:"types"`
 		Stats []struct {
-			BaseStat int `json:"base_stat"`
-			Stat     struct {
-				Name string `json:"name"`
-			} `json:"stat"`
+			BaseStat int           `json:"base_stat"`
+			Stat     namedResource `json:"stat"`
 		} `json:"stats"`
+		Abilities []struct {
+			Ability  namedResource `json:"ability"`
+			IsHidden bool          `json:"is_hidden"`
+			Slot     int           `json:"slot"`
+		} `json:"abilities"`
+		Moves []struct {
+			Move                namedResource `json:"move"`
+			VersionGroupDetails []struct {
+				LevelLearnedAt  int           `json:"level_learned_at"`
+				MoveLearnMethod namedResource `json:"move_learn_method"`
+				VersionGroup    namedResource `json:"version_group"`
+			} `json:"version_group_details"`
+		} `json:"moves"`
 	}
 
 	if err := json.Unmarshal(data, &apiResp); err != nil {
@@ -195,6 +215,7 @@ func (c *Client) parsePokemonResponse(data []byte) (pokemon.Pokemon, error) {
 		Height:         apiResp.Height,
 		Weight:         apiResp.Weight,
 		BaseExperience: apiResp.BaseExperience,
+		SpeciesName:    apiResp.Species.Name,
 		Types:          make([]string, len(apiResp.Types)),
 		Stats:          make(map[string]int),
 	}
@@ -209,20 +230,47 @@ func (c *Client) parsePokemonResponse(data []byte) (pokemon.Pokemon, error) {
 		p.Stats[s.Stat.Name] = s.BaseStat
 	}
 
+	// Convert abilities
+	for _, a := range apiResp.Abilities {
+		p.Abilities = append(p.Abilities, pokemon.PokemonAbility{
+			Name:     a.Ability.Name,
+			IsHidden: a.IsHidden,
+			Slot:     a.Slot,
+		})
+	}
+
+	// Convert moves into a flat learnset
+	for _, m := range apiResp.Moves {
+		for _, d := range m.VersionGroupDetails {
+			p.Learnset = append(p.Learnset, pokemon.LearnsetEntry{
+				Move:         m.Move.Name,
+				Method:       d.MoveLearnMethod.Name,
+				Level:        d.LevelLearnedAt,
+				VersionGroup: d.VersionGroup.Name,
+			})
+		}
+	}
+
 	return p, nil
 }
 
+// locationPage is one page of the location area listing
+type locationPage struct {
+	areas []location.LocationArea
+	next  string
+	prev  string
+}
+
 // parseLocationResponse converts location areas API response
-func (c *Client) parseLocationResponse(data []byte) ([]location.LocationArea, error) {
+func (c *Client) parseLocationResponse(data []byte) (locationPage, error) {
 	var apiResp struct {
-		Results []struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"results"`
+		Next     *string         `json:"next"`
+		Previous *string         `json:"previous"`
+		Results  []namedResource `json:"results"`
 	}
 
 	if err := json.Unmarshal(data, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse location response: %w", err)
+		return locationPage{}, fmt.Errorf("failed to parse location response: %w", err)
 	}
 
 	areas := make([]location.LocationArea, len(apiResp.Results))
@@ -233,7 +281,15 @@ func (c *Client) parseLocationResponse(data []byte) ([]location.LocationArea, er
 		}
 	}
 
-	return areas, nil
+	page := locationPage{areas: areas}
+	if apiResp.Next != nil {
+		page.next = *apiResp.Next
+	}
+	if apiResp.Previous != nil {
+		page.prev = *apiResp.Previous
+	}
+
+	return page, nil
 }
 
 // parseLocationAreaResponse converts specific location area API response
@@ -241,9 +297,7 @@ func (c *Client) parseLocationAreaResponse(data []byte) (location.LocationArea,
 	var apiResp struct {
 		Name              string `json:"name"`
 		PokemonEncounters []struct {
-			Pokemon struct {
-				Name string `json:"name"`
-			} `json:"pokemon"`
+			Pokemon namedResource `json:"pokemon"`
 		} `json:"pokemon_encounters"`
 	}
 
@@ -268,27 +322,3 @@ func (c *Client) parseLocationAreaResponse(data []byte) (location.LocationArea,
 		Pokemon: pokemon,
 	}, nil
 }
-
-// updatePagination updates next and prev URLs from API response
-func (c *Client) updatePagination(data []byte) {
-	var apiResp struct {
-		Next     *string `json:"next"`
-		Previous *string `json:"previous"`
-	}
-
-	if err := json.Unmarshal(data, &apiResp); err != nil {
-		return
-	}
-
-	if apiResp.Next != nil {
-		c.next = *apiResp.Next
-	} else {
-		c.next = ""
-	}
-
-	if apiResp.Previous != nil {
-		c.prev = *apiResp.Previous
-	} else {
-		c.prev = ""
-	}
-}
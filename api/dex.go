@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mcoluomo/pokedexcli/pokemon"
+)
+
+// NationalDex is the name of the Pokedex that lists every species
+const NationalDex = "national"
+
+// GetPokedex fetches a regional Pokedex such as kanto, or NationalDex.
+// Errors wrap ErrNotFound, ErrRateLimited or ErrUpstream.
+func (c *Client) GetPokedex(ctx context.Context, name string) (pokemon.Dex, error) {
+	url := fmt.Sprintf("%s/pokedex/%s", c.baseURL, name)
+	return fetch(ctx, c, url, c.parsePokedexResponse)
+}
+
+// GetGeneration fetches the species introduced in a generation.
+// Errors wrap ErrNotFound, ErrRateLimited or ErrUpstream.
+func (c *Client) GetGeneration(ctx context.Context, name string) (pokemon.Dex, error) {
+	url := fmt.Sprintf("%s/generation/%s", c.baseURL, name)
+	return fetch(ctx, c, url, c.parseGenerationResponse)
+}
+
+// GetGenerations fetches the names of every generation, oldest first.
+// Errors wrap ErrRateLimited or ErrUpstream.
+func (c *Client) GetGenerations(ctx context.Context) ([]string, error) {
+	// There are few enough generations to fit on one page
+	url := fmt.Sprintf("%s/generation/?limit=100", c.baseURL)
+	return fetch(ctx, c, url, c.parseGenerationList)
+}
+
+// parsePokedexResponse converts pokedex API response
+func (c *Client) parsePokedexResponse(data []byte) (pokemon.Dex, error) {
+	var apiResp struct {
+		Name           string `json:"name"`
+		PokemonEntries []struct {
+			EntryNumber    int           `json:"entry_number"`
+			PokemonSpecies namedResource `json:"pokemon_species"`
+		} `json:"pokemon_entries"`
+	}
+
+	if err := json.Unmarshal(data, &apiResp); err != nil {
+		return pokemon.Dex{}, fmt.Errorf("failed to parse pokedex response: %w", err)
+	}
+
+	entries := apiResp.PokemonEntries
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].EntryNumber < entries[j].EntryNumber
+	})
+
+	d := pokemon.Dex{
+		Name:    apiResp.Name,
+		Species: make([]string, len(entries)),
+	}
+	for i, entry := range entries {
+		d.Species[i] = entry.PokemonSpecies.Name
+	}
+	return d, nil
+}
+
+// parseGenerationResponse converts generation API response. PokeAPI lists
+// the species in no particular order, so they are sorted by national number.
+func (c *Client) parseGenerationResponse(data []byte) (pokemon.Dex, error) {
+	var apiResp struct {
+		Name           string          `json:"name"`
+		PokemonSpecies []namedResource `json:"pokemon_species"`
+	}
+
+	if err := json.Unmarshal(data, &apiResp); err != nil {
+		return pokemon.Dex{}, fmt.Errorf("failed to parse generation response: %w", err)
+	}
+
+	species := apiResp.PokemonSpecies
+	sort.SliceStable(species, func(i, j int) bool {
+		return resourceID(species[i].URL) < resourceID(species[j].URL)
+	})
+
+	d := pokemon.Dex{
+		Name:    apiResp.Name,
+		Species: make([]string, len(species)),
+	}
+	for i, s := range species {
+		d.Species[i] = s.Name
+	}
+	return d, nil
+}
+
+// parseGenerationList converts the generation listing to names
+func (c *Client) parseGenerationList(data []byte) ([]string, error) {
+	var apiResp struct {
+		Results []namedResource `json:"results"`
+	}
+
+	if err := json.Unmarshal(data, &apiResp); err != nil {
+		return nil, fmt.Errorf("failed to parse generation list: %w", err)
+	}
+
+	names := make([]string, len(apiResp.Results))
+	for i, result := range apiResp.Results {
+		names[i] = result.Name
+	}
+	return names, nil
+}
+
+// resourceID returns the numeric ID at the end of a resource URL, or 0 if
+// there isn't one
+func resourceID(url string) int {
+	id, err := strconv.Atoi(path.Base(strings.TrimSuffix(url, "/")))
+	if err != nil {
+		return 0
+	}
+	return id
+}
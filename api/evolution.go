@@ -0,0 +1,171 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mcoluomo/pokedexcli/pokemon"
+)
+
+// chainLink mirrors a node of PokeAPI's evolution chain
+type chainLink struct {
+	Species          namedResource     `json:"species"`
+	EvolutionDetails []evolutionDetail `json:"evolution_details"`
+	EvolvesTo        []chainLink       `json:"evolves_to"`
+}
+
+// evolutionDetail mirrors one way of reaching a chain node. Unused
+// conditions are null, zero or empty.
+type evolutionDetail struct {
+	Trigger               namedResource  `json:"trigger"`
+	MinLevel              *int           `json:"min_level"`
+	Item                  *namedResource `json:"item"`
+	HeldItem              *namedResource `json:"held_item"`
+	MinHappiness          *int           `json:"min_happiness"`
+	MinAffection          *int           `json:"min_affection"`
+	MinBeauty             *int           `json:"min_beauty"`
+	TimeOfDay             string         `json:"time_of_day"`
+	Location              *namedResource `json:"location"`
+	KnownMove             *namedResource `json:"known_move"`
+	KnownMoveType         *namedResource `json:"known_move_type"`
+	PartySpecies          *namedResource `json:"party_species"`
+	PartyType             *namedResource `json:"party_type"`
+	TradeSpecies          *namedResource `json:"trade_species"`
+	RelativePhysicalStats *int           `json:"relative_physical_stats"`
+	Gender                *int           `json:"gender"`
+	NeedsOverworldRain    bool           `json:"needs_overworld_rain"`
+	TurnUpsideDown        bool           `json:"turn_upside_down"`
+}
+
+// GetEvolutionChain fetches the evolution chain a species belongs to.
+// Errors wrap ErrNotFound, ErrRateLimited or ErrUpstream.
+func (c *Client) GetEvolutionChain(ctx context.Context, speciesName string) (pokemon.EvolutionChain, error) {
+	// The chain is only reachable through the species resource
+	speciesURL := fmt.Sprintf("%s/pokemon-species/%s", c.baseURL, speciesName)
+	chainURL, err := fetch(ctx, c, speciesURL, c.parseEvolutionChainURL)
+	if err != nil {
+		return pokemon.EvolutionChain{}, err
+	}
+	if chainURL == "" {
+		return pokemon.EvolutionChain{}, fmt.Errorf("%s has no evolution chain: %w", speciesName, ErrNotFound)
+	}
+
+	return fetch(ctx, c, chainURL, c.parseEvolutionChainResponse)
+}
+
+// parseEvolutionChainURL extracts the evolution chain link from a species response
+func (c *Client) parseEvolutionChainURL(data []byte) (string, error) {
+	var apiResp struct {
+		EvolutionChain *struct {
+			URL string `json:"url"`
+		} `json:"evolution_chain"`
+	}
+
+	if err := json.Unmarshal(data, &apiResp); err != nil {
+		return "", fmt.Errorf("failed to parse species response: %w", err)
+	}
+
+	if apiResp.EvolutionChain == nil {
+		return "", nil
+	}
+	return apiResp.EvolutionChain.URL, nil
+}
+
+// parseEvolutionChainResponse converts evolution chain API response
+func (c *Client) parseEvolutionChainResponse(data []byte) (pokemon.EvolutionChain, error) {
+	var apiResp struct {
+		ID    int       `json:"id"`
+		Chain chainLink `json:"chain"`
+	}
+
+	if err := json.Unmarshal(data, &apiResp); err != nil {
+		return pokemon.EvolutionChain{}, fmt.Errorf("failed to parse evolution chain response: %w", err)
+	}
+
+	return pokemon.EvolutionChain{
+		ID:   apiResp.ID,
+		Root: convertChainLink(apiResp.Chain),
+	}, nil
+}
+
+// convertChainLink converts a chain node and its descendants to domain stages
+func convertChainLink(link chainLink) *pokemon.EvolutionStage {
+	stage := &pokemon.EvolutionStage{
+		Species:      link.Species.Name,
+		Requirements: make([]pokemon.EvolutionRequirement, 0, len(link.EvolutionDetails)),
+		EvolvesTo:    make([]*pokemon.EvolutionStage, 0, len(link.EvolvesTo)),
+	}
+
+	for _, d := range link.EvolutionDetails {
+		stage.Requirements = append(stage.Requirements, convertEvolutionDetail(d))
+	}
+
+	for _, next := range link.EvolvesTo {
+		stage.EvolvesTo = append(stage.EvolvesTo, convertChainLink(next))
+	}
+
+	return stage
+}
+
+// convertEvolutionDetail converts one way of evolving to a domain requirement
+func convertEvolutionDetail(d evolutionDetail) pokemon.EvolutionRequirement {
+	req := pokemon.EvolutionRequirement{
+		Trigger:        d.Trigger.Name,
+		MinLevel:       valueOf(d.MinLevel),
+		Item:           nameOf(d.Item),
+		HeldItem:       nameOf(d.HeldItem),
+		MinHappiness:   valueOf(d.MinHappiness),
+		MinAffection:   valueOf(d.MinAffection),
+		MinBeauty:      valueOf(d.MinBeauty),
+		TimeOfDay:      d.TimeOfDay,
+		Location:       nameOf(d.Location),
+		KnownMove:      nameOf(d.KnownMove),
+		KnownMoveType:  nameOf(d.KnownMoveType),
+		PartySpecies:   nameOf(d.PartySpecies),
+		PartyType:      nameOf(d.PartyType),
+		TradeSpecies:   nameOf(d.TradeSpecies),
+		NeedsRain:      d.NeedsOverworldRain,
+		TurnUpsideDown: d.TurnUpsideDown,
+	}
+
+	// PokeAPI compares Attack to Defense as 1, 0 or -1
+	if d.RelativePhysicalStats != nil {
+		switch *d.RelativePhysicalStats {
+		case 1:
+			req.PhysicalStats = pokemon.AttackHigher
+		case 0:
+			req.PhysicalStats = pokemon.AttackEqual
+		case -1:
+			req.PhysicalStats = pokemon.DefenseHigher
+		}
+	}
+
+	// and numbers genders 1 for female and 2 for male
+	if d.Gender != nil {
+		switch *d.Gender {
+		case 1:
+			req.Gender = "female"
+		case 2:
+			req.Gender = "male"
+		}
+	}
+
+	return req
+}
+
+// nameOf returns the name of an optional resource, or "" if it is absent
+func nameOf(r *namedResource) string {
+	if r == nil {
+		return ""
+	}
+	return r.Name
+}
+
+// valueOf returns an optional number, or 0 if it is absent
+func valueOf(n *int) int {
+	if n == nil {
+		return 0
+	}
+	return *n
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mcoluomo/pokedexcli/fakeapi"
+)
+
+func TestGetEvolutionChainDecodesEveryCondition(t *testing.T) {
+	srv, err := fakeapi.NewServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	c := NewClient(WithBaseURL(srv.BaseURL()), WithMemoryCache(), WithRateLimit(RateLimit{}))
+
+	chain, err := c.GetEvolutionChain(context.Background(), "eevee")
+	if err != nil {
+		t.Fatalf("GetEvolutionChain() error = %v", err)
+	}
+
+	tests := []struct {
+		species string
+		want    []string
+	}{
+		{"vaporeon", []string{"use water-stone"}},
+		{"espeon", []string{"level up, friendship 160, during the day"}},
+		{"umbreon", []string{"level up, friendship 160, during the night"}},
+		{"leafeon", []string{"level up, at eterna-forest", "level up, at pinwheel-forest", "level up, at kalos-route-20", "use leaf-stone"}},
+		{"glaceon", []string{"level up, at sinnoh-route-217", "level up, at twist-mountain", "level up, at frost-cavern", "use ice-stone"}},
+		{"sylveon", []string{"level up, affection 2, knowing a fairy move", "level up, friendship 160, knowing a fairy move"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.species, func(t *testing.T) {
+			stage := chain.Find(tt.species)
+			if stage == nil {
+				t.Fatalf("Find(%q) = nil", tt.species)
+			}
+			got := make([]string, len(stage.Requirements))
+			for i, req := range stage.Requirements {
+				got[i] = req.String()
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("requirements = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertEvolutionDetail(t *testing.T) {
+	one, minusOne, two := 1, -1, 2
+
+	tests := []struct {
+		name   string
+		detail evolutionDetail
+		want   string
+	}{
+		{
+			name:   "party species",
+			detail: evolutionDetail{Trigger: namedResource{Name: "level-up"}, PartySpecies: &namedResource{Name: "remoraid"}},
+			want:   "level up, with remoraid in the party",
+		},
+		{
+			name:   "physical stats",
+			detail: evolutionDetail{Trigger: namedResource{Name: "level-up"}, MinLevel: &two, RelativePhysicalStats: &minusOne},
+			want:   "level 2, with attack < defense",
+		},
+		{
+			name:   "gender",
+			detail: evolutionDetail{Trigger: namedResource{Name: "use-item"}, Item: &namedResource{Name: "dawn-stone"}, Gender: &one},
+			want:   "use dawn-stone, female only",
+		},
+		{
+			name:   "known move",
+			detail: evolutionDetail{Trigger: namedResource{Name: "level-up"}, KnownMove: &namedResource{Name: "ancient-power"}},
+			want:   "level up, knowing ancient-power",
+		},
+		{
+			name:   "rain and upside down",
+			detail: evolutionDetail{Trigger: namedResource{Name: "level-up"}, NeedsOverworldRain: true, TurnUpsideDown: true},
+			want:   "level up, in the rain, upside down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := convertEvolutionDetail(tt.detail).String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"sync"
+)
+
+// call is a fetch that is in progress or has just completed
+type call struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	waiters int                // callers still waiting for the result
+	cancel  context.CancelFunc // stops the fetch once nobody is waiting
+}
+
+// inflightGroup deduplicates concurrent fetches of the same URL so that
+// simultaneous cache misses share a single HTTP round-trip
+type inflightGroup struct {
+	mutex sync.Mutex
+	calls map[string]*call
+}
+
+// newInflightGroup creates an empty inflight group
+func newInflightGroup() *inflightGroup {
+	return &inflightGroup{
+		calls: make(map[string]*call),
+	}
+}
+
+// Do runs fn for key unless a call for key is already running, in which
+// case it waits for that call and returns its result. fn gets a context
+// of its own, so a caller whose ctx is done only stops waiting; the shared
+// call is cancelled once every caller has stopped waiting for it.
+func (g *inflightGroup) Do(ctx context.Context, key string, fn func(context.Context) ([]byte, error)) ([]byte, error) {
+	g.mutex.Lock()
+	c, ok := g.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &call{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = c
+		go g.run(callCtx, key, c, fn)
+	}
+	c.waiters++
+	g.mutex.Unlock()
+
+	select {
+	case <-c.done:
+		return c.body, c.err
+	case <-ctx.Done():
+		g.mutex.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			// Later callers start a fresh call rather than join a cancelled one
+			c.cancel()
+			g.forget(key, c)
+		}
+		g.mutex.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// run performs a shared call and wakes everyone waiting for it
+func (g *inflightGroup) run(ctx context.Context, key string, c *call, fn func(context.Context) ([]byte, error)) {
+	c.body, c.err = fn(ctx)
+	c.cancel()
+
+	g.mutex.Lock()
+	g.forget(key, c)
+	g.mutex.Unlock()
+	close(c.done)
+}
+
+// forget removes c from the group if it is still the call for key.
+// Callers must hold the lock.
+func (g *inflightGroup) forget(key string, c *call) {
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+}
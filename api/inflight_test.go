@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestInflightCancelledLeaderDoesNotFailWaiters(t *testing.T) {
+	g := newInflightGroup()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	fn := func(ctx context.Context) ([]byte, error) {
+		close(started)
+		select {
+		case <-release:
+			return []byte("body"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := g.Do(leaderCtx, "url", fn)
+		leaderErr <- err
+	}()
+	<-started
+
+	waiterBody := make(chan []byte, 1)
+	waiterErr := make(chan error, 1)
+	go func() {
+		body, err := g.Do(context.Background(), "url", func(context.Context) ([]byte, error) {
+			t.Error("waiter started a second fetch")
+			return nil, nil
+		})
+		waiterBody <- body
+		waiterErr <- err
+	}()
+
+	// Wait for the waiter to join before the leader gives up
+	for waiters(g, "url") < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	cancelLeader()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("leader error = %v, want context.Canceled", err)
+	}
+
+	close(release)
+	if err := <-waiterErr; err != nil {
+		t.Fatalf("waiter error = %v, want nil", err)
+	}
+	if body := <-waiterBody; string(body) != "body" {
+		t.Errorf("waiter body = %q, want %q", body, "body")
+	}
+}
+
+func TestInflightCancelsCallWhenNobodyWaits(t *testing.T) {
+	g := newInflightGroup()
+	started := make(chan struct{})
+	stopped := make(chan error, 1)
+	fn := func(ctx context.Context) ([]byte, error) {
+		close(started)
+		<-ctx.Done()
+		stopped <- ctx.Err()
+		return nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		g.Do(ctx, "url", fn)
+		close(done)
+	}()
+	<-started
+	cancel()
+	<-done
+
+	select {
+	case err := <-stopped:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("shared call stopped with %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("shared call kept running after every caller gave up")
+	}
+
+	// A new caller must not join the cancelled call
+	body, err := g.Do(context.Background(), "url", func(context.Context) ([]byte, error) {
+		return []byte("fresh"), nil
+	})
+	if err != nil || string(body) != "fresh" {
+		t.Errorf("Do() after cancel = %q, %v, want %q, nil", body, err, "fresh")
+	}
+}
+
+// waiters returns how many callers are waiting for the call for key
+func waiters(g *inflightGroup, key string) int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if c, ok := g.calls[key]; ok {
+		return c.waiters
+	}
+	return 0
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mcoluomo/pokedexcli/pokemon"
+)
+
+// GetMove fetches details of a move.
+// Errors wrap ErrNotFound, ErrRateLimited or ErrUpstream.
+func (c *Client) GetMove(ctx context.Context, name string) (pokemon.Move, error) {
+	url := fmt.Sprintf("%s/move/%s", c.baseURL, name)
+	return fetch(ctx, c, url, c.parseMoveResponse)
+}
+
+// parseMoveResponse converts move API response
+func (c *Client) parseMoveResponse(data []byte) (pokemon.Move, error) {
+	var apiResp struct {
+		Name        string        `json:"name"`
+		Power       *int          `json:"power"`
+		Accuracy    *int          `json:"accuracy"`
+		PP          int           `json:"pp"`
+		Type        namedResource `json:"type"`
+		DamageClass namedResource `json:"damage_class"`
+	}
+
+	if err := json.Unmarshal(data, &apiResp); err != nil {
+		return pokemon.Move{}, fmt.Errorf("failed to parse move response: %w", err)
+	}
+
+	m := pokemon.Move{
+		Name:        apiResp.Name,
+		Type:        apiResp.Type.Name,
+		PP:          apiResp.PP,
+		DamageClass: apiResp.DamageClass.Name,
+	}
+	if apiResp.Power != nil {
+		m.Power = *apiResp.Power
+	}
+	if apiResp.Accuracy != nil {
+		m.Accuracy = *apiResp.Accuracy
+	}
+
+	return m, nil
+}
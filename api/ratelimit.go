@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit controls how fast the client may send requests
+type RateLimit struct {
+	RequestsPerSecond float64 // zero or less disables limiting
+	Burst             int     // requests allowed back to back after idling
+}
+
+// DefaultRateLimit returns a limit that stays within PokeAPI's fair-use policy
+func DefaultRateLimit() RateLimit {
+	return RateLimit{
+		RequestsPerSecond: 5,
+		Burst:             10,
+	}
+}
+
+// WithRateLimit overrides the default rate limit
+func WithRateLimit(limit RateLimit) Option {
+	return func(c *Client) {
+		c.rateLimit = limit
+	}
+}
+
+// rateLimiter is a token bucket shared by every request the client sends
+type rateLimiter struct {
+	mutex  sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // bucket capacity
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter creates a full bucket, or nil if limiting is disabled
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	if limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+
+	burst := float64(max(1, limit.Burst))
+	return &rateLimiter{
+		rate:   limit.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done. A nil limiter
+// never waits.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	// Reserve a token up front, going into debt if necessary, so that
+	// waiters are served in arrival order
+	l.mutex.Lock()
+	l.refill(time.Now())
+	l.tokens--
+	deficit := -l.tokens
+	l.mutex.Unlock()
+
+	if deficit <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(deficit / l.rate * float64(time.Second)))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Hand the reservation back for other requests
+		l.mutex.Lock()
+		l.tokens++
+		l.mutex.Unlock()
+		return ctx.Err()
+	}
+}
+
+// refill adds the tokens earned since the last refill.
+// Callers must hold the lock.
+func (l *rateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+	l.tokens = min(l.burst, l.tokens+elapsed*l.rate)
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/mcoluomo/pokedexcli/cache"
+)
+
+// Errors returned by Client methods, usable with errors.Is
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrRateLimited = errors.New("rate limited by PokeAPI")
+	ErrUpstream    = errors.New("PokeAPI request failed")
+)
+
+// namedResource is PokeAPI's reference to another resource by name and URL
+type namedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// statusError maps a non-OK response status to one of the typed errors
+func statusError(status int) error {
+	switch {
+	case status == http.StatusNotFound:
+		return ErrNotFound
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return fmt.Errorf("%w: status %d", ErrUpstream, status)
+	}
+}
+
+// fetch retrieves the resource at url, from the cache when possible, and
+// decodes it with parse. Concurrent misses for the same URL share one request,
+// which keeps going as long as any of them is still waiting for it.
+func fetch[T any](ctx context.Context, c *Client, url string, parse func([]byte) (T, error)) (T, error) {
+	// Check cache first
+	if data, exists := c.cache.Get(url); exists {
+		return parse(data)
+	}
+
+	body, err := c.inflight.Do(ctx, url, func(ctx context.Context) ([]byte, error) {
+		return c.download(ctx, url)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return parse(body)
+}
+
+// download performs a GET for url and caches a successful response. If a
+// stale copy with validators is cached, the request is made conditional and
+// a 304 refreshes that copy instead of downloading the body again.
+func (c *Client) download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		stale      []byte
+		validators cache.Validators
+		hasStale   bool
+	)
+	store, revalidating := c.cache.(cache.RevalidatingStore)
+	if revalidating {
+		stale, validators, hasStale = store.GetStale(url)
+	}
+	if hasStale {
+		if validators.ETag != "" {
+			req.Header.Set("If-None-Match", validators.ETag)
+		}
+		if validators.LastModified != "" {
+			req.Header.Set("If-Modified-Since", validators.LastModified)
+		}
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && hasStale {
+		store.Refresh(url)
+		return stale, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, statusError(resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read response: %w", ErrUpstream, err)
+	}
+
+	// Cache the response
+	if revalidating {
+		store.SetWithValidators(url, body, cache.Validators{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+		})
+	} else {
+		c.cache.Set(url, body)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how transient request failures are retried
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first; 1 disables retries
+	BaseDelay   time.Duration // delay before the first retry, doubled each time
+	MaxDelay    time.Duration // upper bound for backoff; a longer Retry-After ends retrying
+}
+
+// DefaultRetryPolicy returns the policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   250 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+	}
+}
+
+// WithRetryPolicy overrides the default retry policy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// delay returns how long to wait before the given retry (1-based), using
+// exponential backoff with jitter unless the server asked for longer.
+// Callers don't retry when retryAfter exceeds MaxDelay.
+func (p RetryPolicy) delay(retry int, retryAfter time.Duration) time.Duration {
+	backoff := p.BaseDelay << (retry - 1)
+	if backoff <= 0 || backoff > p.MaxDelay {
+		backoff = p.MaxDelay
+	}
+
+	// Equal jitter: keep half the backoff, randomise the rest
+	half := backoff / 2
+	d := half + time.Duration(rand.Int63n(int64(half)+1))
+
+	return max(d, retryAfter)
+}
+
+// do sends req, retrying transport errors, 429s and 5xx responses
+// according to the client's retry policy. Every attempt is rate limited.
+// If the server asks to wait longer than MaxDelay, do gives up at once
+// rather than retry early.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	maxAttempts := max(1, c.retry.MaxAttempts)
+
+	for attempt := 1; ; attempt++ {
+		var (
+			lastErr    error
+			retryAfter time.Duration
+		)
+
+		if err := c.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		switch {
+		case err != nil:
+			// Cancellation is not a transient failure
+			if req.Context().Err() != nil {
+				return nil, err
+			}
+			lastErr = fmt.Errorf("%w: %w", ErrUpstream, err)
+		case !retryableStatus(resp.StatusCode):
+			return resp, nil
+		default:
+			lastErr = statusError(resp.StatusCode)
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if attempt >= maxAttempts {
+			return nil, fmt.Errorf("giving up after %d attempt(s): %w", attempt, lastErr)
+		}
+		if retryAfter > c.retry.MaxDelay {
+			return nil, fmt.Errorf("server asked to wait %s before retrying: %w", retryAfter, lastErr)
+		}
+		if err := sleep(req.Context(), c.retry.delay(attempt, retryAfter)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retryableStatus reports whether a response status is worth retrying
+func retryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
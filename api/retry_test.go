@@ -0,0 +1,218 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testRetry keeps backoff short so retry tests run quickly
+var testRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 2 * time.Second}
+
+// retryServer answers the nth request (1-based) with handler(n, w) and
+// counts the requests it has seen
+func retryServer(t *testing.T, handler func(n int, w http.ResponseWriter)) (*Client, *httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler(int(hits.Add(1)), w)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient(WithBaseURL(srv.URL), WithRetryPolicy(testRetry), WithRateLimit(RateLimit{}), WithMemoryCache())
+	return c, srv, &hits
+}
+
+// get sends a GET for the server's root through the client's retry loop
+func get(ctx context.Context, t *testing.T, c *Client, srv *httptest.Server) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.do(req)
+	if resp != nil {
+		t.Cleanup(func() { resp.Body.Close() })
+	}
+	return resp, err
+}
+
+func TestDoRetriesServerErrors(t *testing.T) {
+	c, srv, hits := retryServer(t, func(n int, w http.ResponseWriter) {
+		if n < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+
+	resp, err := get(context.Background(), t, c, srv)
+	if err != nil {
+		t.Fatalf("do() error = %v, want nil", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got := hits.Load(); got != 3 {
+		t.Errorf("%d requests, want 3", got)
+	}
+}
+
+func TestDoDoesNotRetryClientErrors(t *testing.T) {
+	c, srv, hits := retryServer(t, func(n int, w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	resp, err := get(context.Background(), t, c, srv)
+	if err != nil {
+		t.Fatalf("do() error = %v, want nil", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("%d requests, want 1", got)
+	}
+}
+
+func TestDoGivesUpAfterMaxAttempts(t *testing.T) {
+	c, srv, hits := retryServer(t, func(n int, w http.ResponseWriter) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := get(context.Background(), t, c, srv)
+	if !errors.Is(err, ErrUpstream) {
+		t.Fatalf("do() error = %v, want %v", err, ErrUpstream)
+	}
+	if !strings.Contains(err.Error(), "giving up after 3 attempt(s)") {
+		t.Errorf("do() error = %q, want the attempt count", err)
+	}
+	if got := hits.Load(); got != 3 {
+		t.Errorf("%d requests, want 3", got)
+	}
+}
+
+func TestDoHonoursRetryAfter(t *testing.T) {
+	c, srv, hits := retryServer(t, func(n int, w http.ResponseWriter) {
+		if n == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("ok"))
+	})
+
+	start := time.Now()
+	resp, err := get(context.Background(), t, c, srv)
+	if err != nil {
+		t.Fatalf("do() error = %v, want nil", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least the 1s Retry-After", elapsed)
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("%d requests, want 2", got)
+	}
+}
+
+func TestDoStopsWhenRetryAfterExceedsMaxDelay(t *testing.T) {
+	c, srv, hits := retryServer(t, func(n int, w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	start := time.Now()
+	_, err := get(context.Background(), t, c, srv)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("do() error = %v, want %v", err, ErrRateLimited)
+	}
+	if elapsed := time.Since(start); elapsed > testRetry.MaxDelay {
+		t.Errorf("gave up after %s, want no wait", elapsed)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("%d requests, want 1", got)
+	}
+}
+
+func TestDoCancelledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, srv, hits := retryServer(t, func(n int, w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		cancel()
+	})
+
+	start := time.Now()
+	_, err := get(ctx, t, c, srv)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("do() error = %v, want %v", err, context.Canceled)
+	}
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("returned after %s, want before the backoff ended", elapsed)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("%d requests, want 1", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{"empty", "", 0},
+		{"seconds", "3", 3 * time.Second},
+		{"zero seconds", "0", 0},
+		{"negative seconds", "-5", 0},
+		{"http date", now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{"date in the past", now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"garbage", "soon", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value, now); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		name       string
+		retry      int
+		retryAfter time.Duration
+		min, max   time.Duration
+	}{
+		{"first retry", 1, 0, 50 * time.Millisecond, 100 * time.Millisecond},
+		{"doubles", 3, 0, 200 * time.Millisecond, 400 * time.Millisecond},
+		{"capped backoff", 10, 0, 500 * time.Millisecond, time.Second},
+		{"retry-after wins", 1, 800 * time.Millisecond, 800 * time.Millisecond, 800 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 20 {
+				if got := p.delay(tt.retry, tt.retryAfter); got < tt.min || got > tt.max {
+					t.Fatalf("delay(%d, %v) = %v, want between %v and %v", tt.retry, tt.retryAfter, got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
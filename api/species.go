@@ -0,0 +1,62 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mcoluomo/pokedexcli/pokemon"
+)
+
+// GetPokemonSpecies fetches species data such as capture rate and flavor text.
+// Errors wrap ErrNotFound, ErrRateLimited or ErrUpstream.
+func (c *Client) GetPokemonSpecies(ctx context.Context, name string) (pokemon.Species, error) {
+	url := fmt.Sprintf("%s/pokemon-species/%s", c.baseURL, name)
+	return fetch(ctx, c, url, c.parseSpeciesResponse)
+}
+
+// parseSpeciesResponse converts pokemon species API response
+func (c *Client) parseSpeciesResponse(data []byte) (pokemon.Species, error) {
+	var apiResp struct {
+		Name              string        `json:"name"`
+		CaptureRate       int           `json:"capture_rate"`
+		IsLegendary       bool          `json:"is_legendary"`
+		IsMythical        bool          `json:"is_mythical"`
+		GrowthRate        namedResource `json:"growth_rate"`
+		Generation        namedResource `json:"generation"`
+		FlavorTextEntries []struct {
+			FlavorText string        `json:"flavor_text"`
+			Language   namedResource `json:"language"`
+		} `json:"flavor_text_entries"`
+	}
+
+	if err := json.Unmarshal(data, &apiResp); err != nil {
+		return pokemon.Species{}, fmt.Errorf("failed to parse species response: %w", err)
+	}
+
+	s := pokemon.Species{
+		Name:        apiResp.Name,
+		CaptureRate: apiResp.CaptureRate,
+		IsLegendary: apiResp.IsLegendary,
+		IsMythical:  apiResp.IsMythical,
+		GrowthRate:  apiResp.GrowthRate.Name,
+		Generation:  apiResp.Generation.Name,
+	}
+
+	// Use the first English entry
+	for _, entry := range apiResp.FlavorTextEntries {
+		if entry.Language.Name == "en" {
+			s.FlavorText = cleanText(entry.FlavorText)
+			break
+		}
+	}
+
+	return s, nil
+}
+
+// cleanText collapses the hard line and page breaks PokeAPI preserves
+// from the games into single spaces
+func cleanText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
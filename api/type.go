@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mcoluomo/pokedexcli/pokemon"
+)
+
+// GetType fetches the damage relations of a type.
+// Errors wrap ErrNotFound, ErrRateLimited or ErrUpstream.
+func (c *Client) GetType(ctx context.Context, name string) (pokemon.TypeRelations, error) {
+	url := fmt.Sprintf("%s/type/%s", c.baseURL, name)
+	return fetch(ctx, c, url, c.parseTypeResponse)
+}
+
+// parseTypeResponse converts type API response
+func (c *Client) parseTypeResponse(data []byte) (pokemon.TypeRelations, error) {
+	var apiResp struct {
+		Name            string `json:"name"`
+		DamageRelations struct {
+			DoubleDamageTo   []namedResource `json:"double_damage_to"`
+			HalfDamageTo     []namedResource `json:"half_damage_to"`
+			NoDamageTo       []namedResource `json:"no_damage_to"`
+			DoubleDamageFrom []namedResource `json:"double_damage_from"`
+			HalfDamageFrom   []namedResource `json:"half_damage_from"`
+			NoDamageFrom     []namedResource `json:"no_damage_from"`
+		} `json:"damage_relations"`
+	}
+
+	if err := json.Unmarshal(data, &apiResp); err != nil {
+		return pokemon.TypeRelations{}, fmt.Errorf("failed to parse type response: %w", err)
+	}
+
+	names := func(resources []namedResource) []string {
+		result := make([]string, len(resources))
+		for i, r := range resources {
+			result[i] = r.Name
+		}
+		return result
+	}
+
+	rel := apiResp.DamageRelations
+	return pokemon.TypeRelations{
+		Name:         apiResp.Name,
+		DoubleTo:     names(rel.DoubleDamageTo),
+		HalfTo:       names(rel.HalfDamageTo),
+		NoDamageTo:   names(rel.NoDamageTo),
+		DoubleFrom:   names(rel.DoubleDamageFrom),
+		HalfFrom:     names(rel.HalfDamageFrom),
+		NoDamageFrom: names(rel.NoDamageFrom),
+	}, nil
+}
@@ -1,65 +1,279 @@
 package cache
 
 import (
+	"container/list"
 	"sync"
 	"time"
 )
 
 // CacheEntry represents a single cache entry
 type CacheEntry struct {
-	data      []byte
-	timestamp time.Time
+	key        string
+	data       []byte
+	timestamp  time.Time
+	validators Validators
 }
 
-// Cache is a simple in-memory cache
+// Validators are the HTTP response headers used to revalidate an entry
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+// IsZero reports whether there is nothing to revalidate with
+func (v Validators) IsZero() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// Cache is a simple in-memory cache with optional size limits
 type Cache struct {
-	entries map[string]CacheEntry
+	entries map[string]*list.Element
+	lru     *list.List // front is most recently used
 	mutex   sync.RWMutex
 	ttl     time.Duration
+	stale   time.Duration // how long revalidatable entries outlive ttl
+	clock   Clock
+	dir     string
+
+	maxEntries  int
+	maxBytes    int
+	bytes       int
+	hits        int
+	misses      int
+	expirations int
+	evictions   int
+
+	reapInterval time.Duration
+	stop         chan struct{}
+	done         chan struct{}
+	closeOnce    sync.Once
+}
+
+// Stats summarises cache effectiveness
+type Stats struct {
+	Entries     int
+	Bytes       int
+	Hits        int
+	Misses      int
+	Expirations int
+	Evictions   int
+}
+
+// HitRate returns the fraction of lookups served from the cache
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0.0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// EntryInfo describes a cached entry without exposing its data
+type EntryInfo struct {
+	Key  string
+	Age  time.Duration
+	Size int
+}
+
+// Option configures optional Cache behaviour
+type Option func(*Cache)
+
+// WithDir persists entries under dir so they survive restarts
+func WithDir(dir string) Option {
+	return func(c *Cache) {
+		c.dir = dir
+	}
+}
+
+// WithClock makes the cache read time from clock instead of the system
+func WithClock(clock Clock) Option {
+	return func(c *Cache) {
+		c.clock = clock
+	}
+}
+
+// WithMaxEntries caps the number of entries; zero means unlimited
+func WithMaxEntries(n int) Option {
+	return func(c *Cache) {
+		c.maxEntries = n
+	}
+}
+
+// WithMaxBytes caps the total size of cached data; zero means unlimited
+func WithMaxBytes(n int) Option {
+	return func(c *Cache) {
+		c.maxBytes = n
+	}
+}
+
+// WithStaleRetention keeps expired entries that carry validators for an
+// extra d so they can be revalidated with GetStale instead of re-downloaded
+func WithStaleRetention(d time.Duration) Option {
+	return func(c *Cache) {
+		c.stale = d
+	}
+}
+
+// WithReaper removes expired entries every interval in the background.
+// Call Close to stop it.
+func WithReaper(interval time.Duration) Option {
+	return func(c *Cache) {
+		c.reapInterval = interval
+	}
 }
 
 // NewCache creates a new cache instance
-func NewCache(ttl time.Duration) *Cache {
-	return &Cache{
-		entries: make(map[string]CacheEntry),
+func NewCache(ttl time.Duration, opts ...Option) *Cache {
+	c := &Cache{
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
 		mutex:   sync.RWMutex{},
 		ttl:     ttl,
+		clock:   realClock{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.dir != "" {
+		c.load()
+	}
+
+	if c.reapInterval > 0 {
+		c.stop = make(chan struct{})
+		c.done = make(chan struct{})
+		go c.reap()
+	}
+
+	return c
+}
+
+// Close stops the background reaper, if any. It is safe to call more than once.
+func (c *Cache) Close() {
+	c.closeOnce.Do(func() {
+		if c.stop == nil {
+			return
+		}
+		close(c.stop)
+		<-c.done
+	})
+}
+
+// reap periodically removes expired entries until Close is called
+func (c *Cache) reap() {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.reapInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.CleanExpired()
+		case <-c.stop:
+			return
+		}
 	}
 }
 
 // Get retrieves data from cache
 func (c *Cache) Get(key string) ([]byte, bool) {
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
 
-	entry, exists := c.entries[key]
+	elem, exists := c.entries[key]
 	if !exists {
+		c.misses++
 		return nil, false
 	}
+	entry := elem.Value.(*CacheEntry)
 
 	// Check if entry has expired
-	if time.Since(entry.timestamp) > c.ttl {
-		// Entry expired, remove it
-		go func() {
-			c.mutex.Lock()
-			delete(c.entries, key)
-			c.mutex.Unlock()
-		}()
+	now := c.clock.Now()
+	if c.expired(entry, now) {
+		// Entry expired, remove it unless it is kept for revalidation
+		if c.purgeable(entry, now) {
+			c.removeElement(elem)
+			c.removeFile(key)
+			c.expirations++
+		}
+		c.misses++
 		return nil, false
 	}
 
+	c.lru.MoveToFront(elem)
+	c.hits++
 	return entry.data, true
 }
 
+// GetStale retrieves an entry along with its validators even if it has
+// expired, so the caller can revalidate it. It does not affect statistics.
+func (c *Cache) GetStale(key string) ([]byte, Validators, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	elem, exists := c.entries[key]
+	if !exists {
+		return nil, Validators{}, false
+	}
+	entry := elem.Value.(*CacheEntry)
+
+	return entry.data, entry.validators, true
+}
+
+// Refresh marks an existing entry as fresh again, e.g. after the origin
+// confirmed it is unchanged. It reports whether the entry was still cached.
+func (c *Cache) Refresh(key string) bool {
+	c.mutex.Lock()
+	elem, exists := c.entries[key]
+	if !exists {
+		c.mutex.Unlock()
+		return false
+	}
+
+	// Replace rather than mutate, the old entry may be being written to disk
+	refreshed := *elem.Value.(*CacheEntry)
+	refreshed.timestamp = c.clock.Now()
+	elem.Value = &refreshed
+	c.lru.MoveToFront(elem)
+	c.mutex.Unlock()
+
+	c.writeFile(&refreshed)
+	return true
+}
+
 // Set stores data in cache
 func (c *Cache) Set(key string, data []byte) {
+	c.SetWithValidators(key, data, Validators{})
+}
+
+// SetWithValidators stores data in cache along with its HTTP validators
+func (c *Cache) SetWithValidators(key string, data []byte, validators Validators) {
+	entry := &CacheEntry{
+		key:        key,
+		data:       data,
+		timestamp:  c.clock.Now(),
+		validators: validators,
+	}
+
+	c.mutex.Lock()
+	c.insert(entry)
+	c.mutex.Unlock()
+
+	// Skipped if the entry was too large to keep or was replaced meanwhile
+	c.writeFile(entry)
+}
+
+// Delete removes an entry from cache
+func (c *Cache) Delete(key string) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	c.entries[key] = CacheEntry{
-		data:      data,
-		timestamp: time.Now(),
+	if elem, exists := c.entries[key]; exists {
+		c.removeElement(elem)
 	}
+	c.removeFile(key)
 }
 
 // Has checks if a key exists in cache (and is not expired)
@@ -73,7 +287,10 @@ func (c *Cache) Clear() {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	c.entries = make(map[string]CacheEntry)
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+	c.bytes = 0
+	c.removeAllFiles()
 }
 
 // Size returns the number of entries in cache
@@ -84,15 +301,129 @@ func (c *Cache) Size() int {
 	return len(c.entries)
 }
 
-// CleanExpired removes all expired entries
-func (c *Cache) CleanExpired() {
+// Bytes returns the total size of cached data
+func (c *Cache) Bytes() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.bytes
+}
+
+// Evictions returns how many entries were dropped to stay within limits
+func (c *Cache) Evictions() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.evictions
+}
+
+// Stats returns a snapshot of the cache counters
+func (c *Cache) Stats() Stats {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return Stats{
+		Entries:     len(c.entries),
+		Bytes:       c.bytes,
+		Hits:        c.hits,
+		Misses:      c.misses,
+		Expirations: c.expirations,
+		Evictions:   c.evictions,
+	}
+}
+
+// Entries lists cached entries from most to least recently used
+func (c *Cache) Entries() []EntryInfo {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	now := c.clock.Now()
+	infos := make([]EntryInfo, 0, len(c.entries))
+	for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+		entry := elem.Value.(*CacheEntry)
+		infos = append(infos, EntryInfo{
+			Key:  entry.key,
+			Age:  now.Sub(entry.timestamp),
+			Size: len(entry.data),
+		})
+	}
+
+	return infos
+}
+
+// CleanExpired removes all expired entries and returns how many were removed.
+// Entries retained for revalidation are kept until their retention ends.
+func (c *Cache) CleanExpired() int {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	now := time.Now()
-	for key, entry := range c.entries {
-		if now.Sub(entry.timestamp) > c.ttl {
-			delete(c.entries, key)
+	now := c.clock.Now()
+	removed := 0
+	for key, elem := range c.entries {
+		if c.purgeable(elem.Value.(*CacheEntry), now) {
+			c.removeElement(elem)
+			c.removeFile(key)
+			removed++
 		}
 	}
+	c.expirations += removed
+
+	return removed
+}
+
+// expired reports whether entry is past its TTL at the given time
+func (c *Cache) expired(entry *CacheEntry, now time.Time) bool {
+	return now.Sub(entry.timestamp) > c.ttl
+}
+
+// purgeable reports whether entry is expired and not worth revalidating
+func (c *Cache) purgeable(entry *CacheEntry, now time.Time) bool {
+	if entry.validators.IsZero() {
+		return c.expired(entry, now)
+	}
+	return now.Sub(entry.timestamp) > c.ttl+c.stale
+}
+
+// insert adds or replaces an entry and evicts down to the limits.
+// Callers must hold the write lock.
+func (c *Cache) insert(entry *CacheEntry) {
+	if elem, exists := c.entries[entry.key]; exists {
+		c.removeElement(elem)
+	}
+
+	// Never flush the whole cache for an entry that cannot fit anyway
+	if c.maxBytes > 0 && len(entry.data) > c.maxBytes {
+		c.removeFile(entry.key)
+		return
+	}
+
+	c.entries[entry.key] = c.lru.PushFront(entry)
+	c.bytes += len(entry.data)
+
+	for c.overLimit() {
+		oldest := c.lru.Back()
+		if oldest == nil {
+			break
+		}
+		key := oldest.Value.(*CacheEntry).key
+		c.removeElement(oldest)
+		c.removeFile(key)
+		c.evictions++
+	}
+}
+
+// overLimit reports whether the cache exceeds its configured limits
+func (c *Cache) overLimit() bool {
+	if c.maxEntries > 0 && len(c.entries) > c.maxEntries {
+		return true
+	}
+	return c.maxBytes > 0 && c.bytes > c.maxBytes
+}
+
+// removeElement drops an entry from the index and LRU list.
+// Callers must hold the write lock.
+func (c *Cache) removeElement(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*CacheEntry)
+	delete(c.entries, entry.key)
+	c.bytes -= len(entry.data)
 }
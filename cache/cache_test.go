@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+const testTTL = time.Minute
+
+var testStart = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestGetAroundExpiry(t *testing.T) {
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		want    bool
+	}{
+		{"just set", 0, true},
+		{"before ttl", testTTL - time.Nanosecond, true},
+		{"exactly at ttl", testTTL, true},
+		{"ttl plus 1ns", testTTL + time.Nanosecond, false},
+		{"long after ttl", 10 * testTTL, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := NewFakeClock(testStart)
+			c := NewCache(testTTL, WithClock(clock))
+			c.Set("key", []byte("value"))
+
+			clock.Advance(tt.elapsed)
+
+			data, ok := c.Get("key")
+			if ok != tt.want {
+				t.Fatalf("Get() ok = %v, want %v", ok, tt.want)
+			}
+			if ok && string(data) != "value" {
+				t.Errorf("Get() = %q, want %q", data, "value")
+			}
+		})
+	}
+}
+
+func TestHasAroundExpiry(t *testing.T) {
+	tests := []struct {
+		name     string
+		elapsed  time.Duration
+		want     bool
+		wantSize int
+	}{
+		{"exactly at ttl", testTTL, true, 1},
+		{"ttl plus 1ns", testTTL + time.Nanosecond, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := NewFakeClock(testStart)
+			c := NewCache(testTTL, WithClock(clock))
+			c.Set("key", []byte("value"))
+
+			clock.Advance(tt.elapsed)
+
+			if got := c.Has("key"); got != tt.want {
+				t.Errorf("Has() = %v, want %v", got, tt.want)
+			}
+			// An expired entry is dropped as soon as it is looked up
+			if got := c.Size(); got != tt.wantSize {
+				t.Errorf("Size() = %d, want %d", got, tt.wantSize)
+			}
+		})
+	}
+}
+
+func TestSetRestartsTTL(t *testing.T) {
+	clock := NewFakeClock(testStart)
+	c := NewCache(testTTL, WithClock(clock))
+
+	c.Set("key", []byte("old"))
+	clock.Advance(testTTL)
+	c.Set("key", []byte("new"))
+	clock.Advance(testTTL)
+
+	data, ok := c.Get("key")
+	if !ok || string(data) != "new" {
+		t.Errorf("Get() = %q, %v, want %q, true", data, ok, "new")
+	}
+	if got := c.Size(); got != 1 {
+		t.Errorf("Size() = %d, want 1", got)
+	}
+}
+
+func TestCleanExpired(t *testing.T) {
+	tests := []struct {
+		name        string
+		ages        map[string]time.Duration // how long before the clean each key was set
+		wantRemoved int
+		wantKept    []string
+	}{
+		{
+			name:        "empty cache",
+			ages:        map[string]time.Duration{},
+			wantRemoved: 0,
+		},
+		{
+			name:        "nothing expired",
+			ages:        map[string]time.Duration{"a": 0, "b": testTTL},
+			wantRemoved: 0,
+			wantKept:    []string{"a", "b"},
+		},
+		{
+			name:        "boundary",
+			ages:        map[string]time.Duration{"at": testTTL, "past": testTTL + time.Nanosecond},
+			wantRemoved: 1,
+			wantKept:    []string{"at"},
+		},
+		{
+			name:        "all expired",
+			ages:        map[string]time.Duration{"a": 2 * testTTL, "b": 3 * testTTL, "c": testTTL + time.Nanosecond},
+			wantRemoved: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := NewFakeClock(testStart)
+			c := NewCache(testTTL, WithClock(clock))
+
+			now := testStart.Add(10 * testTTL)
+			for key, age := range tt.ages {
+				clock.Set(now.Add(-age))
+				c.Set(key, []byte(key))
+			}
+			clock.Set(now)
+
+			if got := c.CleanExpired(); got != tt.wantRemoved {
+				t.Errorf("CleanExpired() = %d, want %d", got, tt.wantRemoved)
+			}
+			if got := c.Size(); got != len(tt.wantKept) {
+				t.Errorf("Size() = %d, want %d", got, len(tt.wantKept))
+			}
+			for _, key := range tt.wantKept {
+				if !c.Has(key) {
+					t.Errorf("Has(%q) = false after clean, want true", key)
+				}
+			}
+			if got := c.Stats().Expirations; got != tt.wantRemoved {
+				t.Errorf("Stats().Expirations = %d, want %d", got, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestCleanExpiredKeepsRevalidatableEntries(t *testing.T) {
+	clock := NewFakeClock(testStart)
+	c := NewCache(testTTL, WithClock(clock), WithStaleRetention(testTTL))
+	c.SetWithValidators("etag", []byte("value"), Validators{ETag: `"v1"`})
+	c.Set("plain", []byte("value"))
+
+	clock.Advance(testTTL + time.Nanosecond)
+	if got := c.CleanExpired(); got != 1 {
+		t.Errorf("CleanExpired() within retention = %d, want 1", got)
+	}
+	if _, _, ok := c.GetStale("etag"); !ok {
+		t.Error("GetStale() = false within retention, want true")
+	}
+
+	clock.Advance(testTTL)
+	if got := c.CleanExpired(); got != 1 {
+		t.Errorf("CleanExpired() after retention = %d, want 1", got)
+	}
+}
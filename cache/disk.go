@@ -0,0 +1,198 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Persistence is best effort: the cache is only an optimisation, so disk
+// errors fall back to in-memory behaviour instead of failing callers.
+
+const (
+	entryExt = ".json"
+	tmpExt   = ".tmp"
+)
+
+// diskEntry is the on-disk representation of a CacheEntry
+type diskEntry struct {
+	Key          string    `json:"key"`
+	Timestamp    time.Time `json:"timestamp"`
+	Data         []byte    `json:"data"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+}
+
+// DefaultDir returns the per-user cache directory for the Pokedex
+func DefaultDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "pokedexcli"), nil
+}
+
+// fileName maps a cache key to a file name that is safe on any filesystem
+func (c *Cache) fileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+entryExt)
+}
+
+// load rehydrates unexpired entries from disk, discarding anything unreadable
+func (c *Cache) load() {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		c.dir = ""
+		return
+	}
+
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+
+	now := c.clock.Now()
+	var loaded []*CacheEntry
+	for _, f := range files {
+		path := filepath.Join(c.dir, f.Name())
+
+		// Leftovers from an interrupted write
+		if strings.HasSuffix(f.Name(), tmpExt) {
+			os.Remove(path)
+			continue
+		}
+		if f.IsDir() || !strings.HasSuffix(f.Name(), entryExt) {
+			continue
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var de diskEntry
+		if err := json.Unmarshal(data, &de); err != nil || c.fileName(de.Key) != path {
+			// Corrupt or foreign file
+			os.Remove(path)
+			continue
+		}
+
+		entry := &CacheEntry{
+			key:       de.Key,
+			data:      de.Data,
+			timestamp: de.Timestamp,
+			validators: Validators{
+				ETag:         de.ETag,
+				LastModified: de.LastModified,
+			},
+		}
+		if c.purgeable(entry, now) {
+			os.Remove(path)
+			continue
+		}
+
+		loaded = append(loaded, entry)
+	}
+
+	// Insert oldest first so the newest entries survive any limits
+	sort.Slice(loaded, func(i, j int) bool {
+		return loaded[i].timestamp.Before(loaded[j].timestamp)
+	})
+	for _, entry := range loaded {
+		c.insert(entry)
+	}
+}
+
+// writeFile atomically persists an entry by writing a temp file and renaming
+// it. The temp file is written without holding the lock; the rename happens
+// under it, and only if entry is still the cached copy of its key, so a
+// concurrent Delete, Clear or eviction is never undone by a late write.
+func (c *Cache) writeFile(entry *CacheEntry) {
+	if c.dir == "" {
+		return
+	}
+
+	tmpName, ok := c.writeTemp(entry)
+	if !ok {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, exists := c.entries[entry.key]; !exists || elem.Value != entry {
+		os.Remove(tmpName)
+		return
+	}
+	if err := os.Rename(tmpName, c.fileName(entry.key)); err != nil {
+		os.Remove(tmpName)
+	}
+}
+
+// writeTemp writes an entry to a new temp file and returns its name
+func (c *Cache) writeTemp(entry *CacheEntry) (string, bool) {
+	data, err := json.Marshal(diskEntry{
+		Key:          entry.key,
+		Timestamp:    entry.timestamp,
+		Data:         entry.data,
+		ETag:         entry.validators.ETag,
+		LastModified: entry.validators.LastModified,
+	})
+	if err != nil {
+		return "", false
+	}
+
+	tmp, err := os.CreateTemp(c.dir, "entry-*"+tmpExt)
+	if err != nil {
+		return "", false
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return "", false
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return "", false
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return "", false
+	}
+
+	return tmpName, true
+}
+
+// removeFile deletes the persisted copy of an entry
+func (c *Cache) removeFile(key string) {
+	if c.dir == "" {
+		return
+	}
+	os.Remove(c.fileName(key))
+}
+
+// removeAllFiles deletes every persisted entry
+func (c *Cache) removeAllFiles() {
+	if c.dir == "" {
+		return
+	}
+
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+
+	for _, f := range files {
+		name := f.Name()
+		if strings.HasSuffix(name, entryExt) || strings.HasSuffix(name, tmpExt) {
+			os.Remove(filepath.Join(c.dir, name))
+		}
+	}
+}
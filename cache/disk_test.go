@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDiskRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	clock := NewFakeClock(testStart)
+
+	c := NewCache(testTTL, WithDir(dir), WithClock(clock))
+	c.SetWithValidators("url", []byte("body"), Validators{ETag: `"v1"`, LastModified: "yesterday"})
+	c.Set("other", []byte("data"))
+
+	clock.Advance(testTTL / 2)
+	reopened := NewCache(testTTL, WithDir(dir), WithClock(clock))
+
+	data, ok := reopened.Get("url")
+	if !ok || string(data) != "body" {
+		t.Fatalf("Get() after reopen = %q, %v, want %q, true", data, ok, "body")
+	}
+	_, validators, _ := reopened.GetStale("url")
+	if validators.ETag != `"v1"` || validators.LastModified != "yesterday" {
+		t.Errorf("validators after reopen = %+v", validators)
+	}
+	if got := reopened.Size(); got != 2 {
+		t.Errorf("Size() after reopen = %d, want 2", got)
+	}
+
+	// The original timestamp is kept, so the TTL isn't restarted by reloading
+	clock.Advance(testTTL/2 + time.Nanosecond)
+	if reopened.Has("url") {
+		t.Error("Has() = true after the original TTL passed, want false")
+	}
+}
+
+func TestDiskDeleteAndClearRemoveFiles(t *testing.T) {
+	dir := t.TempDir()
+	c := NewCache(testTTL, WithDir(dir))
+	c.Set("a", []byte("a"))
+	c.Set("b", []byte("b"))
+	c.Set("c", []byte("c"))
+
+	c.Delete("a")
+	if got := countFiles(t, dir); got != 2 {
+		t.Errorf("%d files after Delete, want 2", got)
+	}
+
+	c.Clear()
+	if got := countFiles(t, dir); got != 0 {
+		t.Errorf("%d files after Clear, want 0", got)
+	}
+	if got := NewCache(testTTL, WithDir(dir)).Size(); got != 0 {
+		t.Errorf("Size() after reopening a cleared cache = %d, want 0", got)
+	}
+}
+
+func TestDiskLoadDiscardsBadFiles(t *testing.T) {
+	clock := NewFakeClock(testStart)
+	valid := diskEntry{Key: "valid", Timestamp: testStart, Data: []byte("ok")}
+	expired := diskEntry{Key: "expired", Timestamp: testStart.Add(-testTTL - time.Nanosecond), Data: []byte("old")}
+
+	tests := []struct {
+		name     string
+		file     string // file name relative to the cache dir; "" uses the key's name
+		entry    *diskEntry
+		raw      string // written instead of entry when set
+		wantKept bool
+	}{
+		{"valid entry", "", &valid, "", true},
+		{"expired entry", "", &expired, "", false},
+		{"leftover temp file", "entry-123" + tmpExt, nil, `{"key":"tmp"}`, false},
+		{"truncated json", "0000" + entryExt, nil, `{"key":"valid","data":`, false},
+		{"not json", "1111" + entryExt, nil, "garbage", false},
+		// A well-formed entry whose name doesn't match its key, e.g. copied in by hand
+		{"foreign entry", "2222" + entryExt, &valid, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			probe := &Cache{dir: dir}
+
+			data := []byte(tt.raw)
+			if tt.entry != nil {
+				var err error
+				if data, err = json.Marshal(tt.entry); err != nil {
+					t.Fatal(err)
+				}
+			}
+			path := filepath.Join(dir, tt.file)
+			if tt.file == "" {
+				path = probe.fileName(tt.entry.Key)
+			}
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			c := NewCache(testTTL, WithDir(dir), WithClock(clock))
+
+			wantSize, wantFiles := 0, 0
+			if tt.wantKept {
+				wantSize, wantFiles = 1, 1
+			}
+			if got := c.Size(); got != wantSize {
+				t.Errorf("Size() = %d, want %d", got, wantSize)
+			}
+			if got := countFiles(t, dir); got != wantFiles {
+				t.Errorf("%d files left on disk, want %d", got, wantFiles)
+			}
+		})
+	}
+}
+
+func TestDiskLoadRespectsLimits(t *testing.T) {
+	dir := t.TempDir()
+	clock := NewFakeClock(testStart)
+
+	c := NewCache(testTTL, WithDir(dir), WithClock(clock))
+	for _, key := range []string{"oldest", "middle", "newest"} {
+		c.Set(key, []byte(key))
+		clock.Advance(time.Second)
+	}
+
+	reopened := NewCache(testTTL, WithDir(dir), WithClock(clock), WithMaxEntries(2))
+	if reopened.Has("oldest") {
+		t.Error("oldest entry was rehydrated past the entry limit")
+	}
+	if !reopened.Has("newest") || !reopened.Has("middle") {
+		t.Error("newest entries were not rehydrated")
+	}
+	if got := countFiles(t, dir); got != 2 {
+		t.Errorf("%d files left on disk, want 2", got)
+	}
+}
+
+// countFiles returns the number of files in dir
+func countFiles(t *testing.T, dir string) int {
+	t.Helper()
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(files)
+}
@@ -0,0 +1,64 @@
+package cache
+
+// Store is the contract for a response cache backend. The in-memory Cache
+// implements it, as does NoopStore; other backends (files, bbolt, SQLite)
+// can be plugged into api.Client the same way.
+type Store interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, data []byte)
+	Delete(key string)
+	Clear()
+	Size() int
+}
+
+// RevalidatingStore is a Store that keeps HTTP validators so expired entries
+// can be refreshed with a conditional request
+type RevalidatingStore interface {
+	Store
+	GetStale(key string) ([]byte, Validators, bool)
+	Refresh(key string) bool
+	SetWithValidators(key string, data []byte, validators Validators)
+}
+
+// InspectableStore is a Store that can report on its contents
+type InspectableStore interface {
+	Store
+	Stats() Stats
+	Entries() []EntryInfo
+	CleanExpired() int
+}
+
+// Closer is implemented by stores that hold resources such as goroutines
+type Closer interface {
+	Close()
+}
+
+// NoopStore is a Store that never caches anything
+type NoopStore struct{}
+
+// Get always misses
+func (NoopStore) Get(key string) ([]byte, bool) {
+	return nil, false
+}
+
+// Set discards data
+func (NoopStore) Set(key string, data []byte) {}
+
+// Delete does nothing
+func (NoopStore) Delete(key string) {}
+
+// Clear does nothing
+func (NoopStore) Clear() {}
+
+// Size is always zero
+func (NoopStore) Size() int {
+	return 0
+}
+
+var (
+	_ Store             = (*Cache)(nil)
+	_ RevalidatingStore = (*Cache)(nil)
+	_ InspectableStore  = (*Cache)(nil)
+	_ Closer            = (*Cache)(nil)
+	_ Store             = NoopStore{}
+)
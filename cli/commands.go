@@ -1,30 +1,165 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/mcoluomo/pokedexcli/api"
+	"github.com/mcoluomo/pokedexcli/cache"
 	"github.com/mcoluomo/pokedexcli/location"
 	"github.com/mcoluomo/pokedexcli/pokemon"
+	"github.com/mcoluomo/pokedexcli/profile"
+	"github.com/mcoluomo/pokedexcli/sqlitestore"
 )
 
 // App contains all the application services
 type App struct {
 	client          *api.Client
 	pokedex         *pokemon.Pokedex
+	repo            pokemon.PokedexRepository
 	catchService    *pokemon.CatchService
 	locationService *location.LocationService
+	typeChart       *pokemon.TypeChart
+	lastArea        location.LocationArea // most recently explored area
+	profiles        *profile.Manager      // nil when saving is disabled
+	profile         profile.Profile
+	legacySave      string // pre-profile save file to import into the default profile
+	canSave         bool   // false until the stored Pokedex is known to be safe to overwrite
+}
+
+// Config holds startup settings for the application
+type Config struct {
+	BaseURL     string // PokeAPI root; empty uses api.DefaultBaseURL
+	ProfileDir  string // where profiles are kept; empty disables saving
+	Profile     string // profile to start with; empty uses profile.DefaultName
+	NoDiskCache bool   // keep API responses in memory only
+	LegacySave  string // save file from before profiles, imported into an empty default profile
 }
 
 // NewApp creates a new application instance
-func NewApp() *App {
-	return &App{
-		client:          api.NewClient(),
+func NewApp(cfg Config) *App {
+	var clientOpts []api.Option
+	if cfg.BaseURL != "" {
+		clientOpts = append(clientOpts, api.WithBaseURL(cfg.BaseURL))
+	}
+	if cfg.NoDiskCache {
+		clientOpts = append(clientOpts, api.WithMemoryCache())
+	}
+
+	app := &App{
+		client:          api.NewClient(clientOpts...),
 		pokedex:         pokemon.NewPokedex(),
+		repo:            pokemon.NewMemoryRepository(),
 		catchService:    pokemon.NewCatchService(),
 		locationService: location.NewLocationService(),
+		typeChart:       pokemon.DefaultTypeChart(),
+		legacySave:      cfg.LegacySave,
+		canSave:         true,
+	}
+
+	if cfg.ProfileDir != "" {
+		name := cfg.Profile
+		if name == "" {
+			name = profile.DefaultName
+		}
+
+		app.profiles = profile.NewManager(cfg.ProfileDir)
+		p, err := app.profiles.Open(name, time.Now())
+		if err != nil {
+			fmt.Printf("Couldn't open profile %s, your progress won't be saved: %v\n", name, err)
+		} else {
+			app.useProfile(p)
+		}
+	}
+
+	return app
+}
+
+// useProfile makes p the active profile and loads its Pokedex. If the
+// profile's database can't be opened, progress is kept in memory only.
+func (app *App) useProfile(p profile.Profile) {
+	app.repo.Close()
+
+	app.profile = p
+	app.pokedex = pokemon.NewPokedex()
+	app.canSave = false
+
+	repo, err := sqlitestore.Open(p.DatabasePath())
+	if err != nil {
+		fmt.Printf("Couldn't open the Pokedex of %s, your progress won't be saved: %v\n", p.Name, err)
+		app.repo = pokemon.NewMemoryRepository()
+		return
+	}
+	app.repo = repo
+	app.loadSave()
+}
+
+// loadSave restores the Pokedex from the repository, importing a JSON save
+// from before profiles moved to SQLite, or from before there were profiles,
+// if there is one. Saving stays off if the stored Pokedex can't be read, so
+// it is never overwritten by mistake.
+func (app *App) loadSave() {
+	pd, err := app.repo.Load()
+	if err != nil {
+		fmt.Printf("Couldn't load your Pokedex: %v\n", err)
+		fmt.Println("Autosave is off until you 'load' or 'save' successfully.")
+		return
+	}
+	app.pokedex = pd
+	app.canSave = true
+
+	if pd.Count() == 0 && app.profile.Name != "" {
+		app.importLegacySave(app.profile.LegacySavePath())
+	}
+	if app.pokedex.Count() == 0 && app.profile.Name == profile.DefaultName && app.legacySave != "" {
+		app.importLegacySave(app.legacySave)
+	}
+}
+
+// importLegacySave moves a JSON save into the repository, renaming the file
+// afterwards so it is only imported once
+func (app *App) importLegacySave(path string) {
+	pd, err := pokemon.LoadPokedex(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		fmt.Printf("Couldn't import your old save file: %v\n", err)
+		return
+	}
+
+	if err := app.repo.Save(pd); err != nil {
+		fmt.Printf("Couldn't import your old save file: %v\n", err)
+		return
+	}
+	app.pokedex = pd
+	if err := os.Rename(path, path+".imported"); err != nil {
+		fmt.Printf("Warning: couldn't rename %s after importing it: %v\n", path, err)
+	}
+
+	fmt.Printf("Imported %d Pokemon from %s.\n", pd.Count(), path)
+}
+
+// autoSave saves the Pokedex after a change if the profile wants it,
+// warning instead of failing the command if it can't
+func (app *App) autoSave() {
+	if !app.canSave || (app.profile.Name != "" && !app.profile.Settings.Autosave) {
+		return
 	}
+	if err := app.repo.Save(app.pokedex); err != nil {
+		fmt.Printf("Warning: couldn't save your Pokedex: %v\n", err)
+	}
+}
+
+// Close saves the Pokedex and releases resources held by the application services
+func (app *App) Close() {
+	app.autoSave()
+	app.repo.Close()
+	app.client.Close()
 }
 
 // Command represents a CLI command
@@ -32,7 +167,8 @@ type Command struct {
 	Name        string
 	Description string
 	RequiresArg bool
-	Callback    func(*App, string) error
+	KeepCase    bool // pass the argument as typed, e.g. for file paths
+	Callback    func(*App, context.Context, string) error
 }
 
 // GetCommands returns all available commands
@@ -76,21 +212,108 @@ func GetCommands() map[string]Command {
 		},
 		"inspect": {
 			Name:        "inspect",
-			Description: "View details of a caught Pokemon",
+			Description: "View details of a caught Pokemon by ID, nickname or name",
 			RequiresArg: true,
 			Callback:    (*App).InspectCommand,
 		},
+		"release": {
+			Name:        "release",
+			Description: "Release a caught Pokemon by ID, nickname or name",
+			RequiresArg: true,
+			Callback:    (*App).ReleaseCommand,
+		},
+		"nickname": {
+			Name:        "nickname",
+			Description: "Give a caught Pokemon a nickname, or clear it (nickname <pokemon> [nickname])",
+			RequiresArg: true,
+			KeepCase:    true,
+			Callback:    (*App).NicknameCommand,
+		},
 		"pokedex": {
 			Name:        "pokedex",
-			Description: "List all caught Pokemon",
+			Description: "List caught or seen Pokemon, what's missing and how complete your Pokedex is (pokedex [--sort bst] [--type fire] [--min-stat attack=80] [--legendary], pokedex seen|missing|completion [--dex kanto])",
 			RequiresArg: false,
 			Callback:    (*App).PokedexCommand,
 		},
+		"save": {
+			Name:        "save",
+			Description: "Save the Pokedex, or export it as JSON (save [path])",
+			RequiresArg: false,
+			KeepCase:    true,
+			Callback:    (*App).SaveCommand,
+		},
+		"load": {
+			Name:        "load",
+			Description: "Reload the saved Pokedex, or import one exported as JSON (load [path])",
+			RequiresArg: false,
+			KeepCase:    true,
+			Callback:    (*App).LoadCommand,
+		},
+		"profile": {
+			Name:        "profile",
+			Description: "Manage trainer profiles (profile new|list|switch|delete <name>, profile set autosave on|off)",
+			RequiresArg: true,
+			Callback:    (*App).ProfileCommand,
+		},
+		"history": {
+			Name:        "history",
+			Description: "Show recent catches and explorations (history [--since 7d] [--area name] [--caught])",
+			RequiresArg: false,
+			Callback:    (*App).HistoryCommand,
+		},
+		"evolutions": {
+			Name:        "evolutions",
+			Description: "Show the evolution chain of a Pokemon",
+			RequiresArg: true,
+			Callback:    (*App).EvolutionsCommand,
+		},
+		"evolve": {
+			Name:        "evolve",
+			Description: "Evolve a caught Pokemon, optionally using an item (evolve <pokemon> [item])",
+			RequiresArg: true,
+			Callback:    (*App).EvolveCommand,
+		},
+		"train": {
+			Name:        "train",
+			Description: "Level up a caught Pokemon, raising its friendship (train <pokemon> [levels])",
+			RequiresArg: true,
+			Callback:    (*App).TrainCommand,
+		},
+		"give": {
+			Name:        "give",
+			Description: "Give a caught Pokemon an item to hold, or take it back (give <pokemon> [item])",
+			RequiresArg: true,
+			Callback:    (*App).GiveCommand,
+		},
+		"moves": {
+			Name:        "moves",
+			Description: "List the moves a Pokemon can learn (moves <pokemon> [--method level-up|machine|egg] [--version ...])",
+			RequiresArg: true,
+			Callback:    (*App).MovesCommand,
+		},
+		"ability": {
+			Name:        "ability",
+			Description: "Describe an ability and list the Pokemon that can have it",
+			RequiresArg: true,
+			Callback:    (*App).AbilityCommand,
+		},
+		"matchup": {
+			Name:        "matchup",
+			Description: "Show weaknesses, resistances and immunities of a Pokemon or type",
+			RequiresArg: true,
+			Callback:    (*App).MatchupCommand,
+		},
+		"cache": {
+			Name:        "cache",
+			Description: "Manage the API cache (stats, clear, list, purge-expired)",
+			RequiresArg: true,
+			Callback:    (*App).CacheCommand,
+		},
 	}
 }
 
 // HelpCommand displays help information
-func (app *App) HelpCommand(args string) error {
+func (app *App) HelpCommand(ctx context.Context, args string) error {
 	fmt.Println("\nWelcome to the Pokedex!")
 	fmt.Printf("Usage:\n")
 
@@ -108,20 +331,21 @@ func (app *App) HelpCommand(args string) error {
 }
 
 // ExitCommand exits the application
-func (app *App) ExitCommand(args string) error {
+func (app *App) ExitCommand(ctx context.Context, args string) error {
 	fmt.Println("Closing the Pokedex... Goodbye!")
+	app.Close()
 	os.Exit(0)
 	return nil
 }
 
 // MapCommand displays location areas
-func (app *App) MapCommand(args string) error {
+func (app *App) MapCommand(ctx context.Context, args string) error {
 	if !app.client.HasNext() {
 		fmt.Println("You're on the last page!")
 		return nil
 	}
 
-	areas, err := app.client.GetLocationAreas()
+	areas, err := app.client.GetLocationAreas(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get location areas: %w", err)
 	}
@@ -131,13 +355,13 @@ func (app *App) MapCommand(args string) error {
 }
 
 // MapBackCommand displays previous location areas
-func (app *App) MapBackCommand(args string) error {
+func (app *App) MapBackCommand(ctx context.Context, args string) error {
 	if !app.client.HasPrev() {
 		fmt.Println("You're on the first page!")
 		return nil
 	}
 
-	areas, err := app.client.GetPreviousLocationAreas()
+	areas, err := app.client.GetPreviousLocationAreas(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get previous location areas: %w", err)
 	}
@@ -147,78 +371,289 @@ func (app *App) MapBackCommand(args string) error {
 }
 
 // ExploreCommand explores a specific location
-func (app *App) ExploreCommand(areaName string) error {
+func (app *App) ExploreCommand(ctx context.Context, areaName string) error {
 	if areaName == "" {
 		return fmt.Errorf("please provide an area name to explore")
 	}
 
-	area, err := app.client.ExploreLocation(areaName)
+	area, err := app.client.ExploreLocation(ctx, areaName)
+	if errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("there is no location area called %s", areaName)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to explore %s: %w", areaName, err)
 	}
 
+	app.lastArea = area
 	app.locationService.ExploreArea(area)
+
+	now := time.Now()
+	newlySeen := 0
+	for _, name := range area.Pokemon {
+		if app.pokedex.See(name, now) {
+			newlySeen++
+		}
+	}
+	if newlySeen > 0 {
+		fmt.Printf("%d new Pokemon were added to your Pokedex as seen.\n", newlySeen)
+		app.autoSave()
+	}
+
+	app.record(app.repo.RecordExploration(pokemon.Exploration{
+		Time:    now,
+		Area:    area.Name,
+		Pokemon: area.Pokemon,
+	}))
 	return nil
 }
 
 // CatchCommand attempts to catch a Pokemon
-func (app *App) CatchCommand(pokemonName string) error {
+func (app *App) CatchCommand(ctx context.Context, pokemonName string) error {
 	if pokemonName == "" {
 		return fmt.Errorf("please provide a Pokemon name to catch")
 	}
 
-	// Check if already caught
-	if app.pokedex.HasCaught(pokemonName) {
-		fmt.Printf("You have already caught %s! Use 'inspect %s' to view it.\n", pokemonName, pokemonName)
-		return nil
-	}
-
 	fmt.Printf("Throwing a Pokeball at %s...\n", pokemonName)
 
 	// Get Pokemon from API
-	p, err := app.client.GetPokemon(pokemonName)
+	p, err := app.lookupPokemon(ctx, pokemonName)
+	if errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("there is no Pokemon called %s", pokemonName)
+	}
 	if err != nil {
-		return fmt.Errorf("couldn't find Pokemon %s: %w", pokemonName, err)
+		return fmt.Errorf("failed to fetch Pokemon %s: %w", pokemonName, err)
 	}
 
 	// Use domain logic to attempt catch
 	caught, rate := app.catchService.AttemptCatch(p)
+	attempt := pokemon.CatchAttempt{
+		Time:     time.Now(),
+		Pokemon:  p.Name,
+		Location: app.catchLocation(p.Name),
+		Rate:     rate,
+		Caught:   caught,
+	}
 
 	if caught {
-		app.pokedex.Catch(p)
+		c := app.pokedex.Catch(app.catchService.Capture(p, attempt.Time, attempt.Location))
+		attempt.CaughtID = c.ID
 		fmt.Printf("%s was caught! (catch rate: %.2f)\n", pokemonName, rate)
-		fmt.Printf("You may now inspect it with the 'inspect %s' command.\n", pokemonName)
+		fmt.Printf("It was added to your Pokedex as #%d. You may now inspect it with the 'inspect %d' command.\n", c.ID, c.ID)
+		app.autoSave()
 	} else {
 		fmt.Printf("%s escaped! (catch rate: %.2f)\n", pokemonName, rate)
 		fmt.Printf("Try again with the 'catch %s' command.\n", pokemonName)
+		if app.pokedex.See(p.Name, attempt.Time) {
+			fmt.Printf("%s was added to your Pokedex as seen.\n", p.Name)
+			app.autoSave()
+		}
 	}
 
+	app.record(app.repo.RecordCatchAttempt(attempt))
 	return nil
 }
 
+// record reports a failure to write to the activity log without failing
+// the command that was logged
+func (app *App) record(err error) {
+	if err != nil {
+		fmt.Printf("Warning: couldn't update your activity log: %v\n", err)
+	}
+}
+
+// catchLocation returns the explored area a Pokemon was caught in, or ""
+// if it doesn't live in the most recently explored area
+func (app *App) catchLocation(pokemonName string) string {
+	for _, name := range app.lastArea.Pokemon {
+		if name == pokemonName {
+			return app.lastArea.Name
+		}
+	}
+	return ""
+}
+
+// findCaught resolves an ID, nickname or Pokemon name to a caught Pokemon
+func (app *App) findCaught(ref string) (pokemon.CaughtPokemon, error) {
+	c, err := app.pokedex.Find(ref)
+	if errors.Is(err, pokemon.ErrNotCaught) {
+		return c, fmt.Errorf("you haven't caught %s yet! Use 'pokedex' to list your Pokemon", ref)
+	}
+	return c, err
+}
+
+// lookupPokemon fetches a Pokemon along with its species data. Species data
+// only refines the catch rules, so failing to fetch it is not fatal.
+func (app *App) lookupPokemon(ctx context.Context, name string) (pokemon.Pokemon, error) {
+	p, err := app.client.GetPokemon(ctx, name)
+	if err != nil {
+		return pokemon.Pokemon{}, err
+	}
+
+	speciesName := p.SpeciesName
+	if speciesName == "" {
+		speciesName = p.Name
+	}
+
+	species, err := app.client.GetPokemonSpecies(ctx, speciesName)
+	if ctx.Err() != nil {
+		return pokemon.Pokemon{}, ctx.Err()
+	}
+	if err == nil {
+		p.Species = &species
+	}
+
+	return p, nil
+}
+
 // InspectCommand displays details of a caught Pokemon
-func (app *App) InspectCommand(pokemonName string) error {
-	if pokemonName == "" {
-		return fmt.Errorf("please provide a Pokemon name to inspect")
+func (app *App) InspectCommand(ctx context.Context, ref string) error {
+	if ref == "" {
+		return fmt.Errorf("please provide a Pokemon ID, nickname or name to inspect")
 	}
 
-	p, exists := app.pokedex.Get(pokemonName)
-	if !exists {
-		return fmt.Errorf("you haven't caught %s yet! Use 'catch %s' to catch it first", pokemonName, pokemonName)
+	p, err := app.findCaught(ref)
+	if err != nil {
+		return err
 	}
 
-	fmt.Printf("\n=== %s ===\n", p.Name)
+	// Ability effects are a nice-to-have, show names only if they can't be fetched
+	abilities := make([]pokemon.PokemonAbility, len(p.Abilities))
+	for i, a := range p.Abilities {
+		if details, err := app.client.GetAbility(ctx, a.Name); err == nil {
+			a.Effect = details.ShortEffect
+		}
+		abilities[i] = a
+	}
+	p.Abilities = abilities
+
+	fmt.Printf("\n=== %s ===\n", p.DisplayName())
 	fmt.Print(p.String())
 	fmt.Println()
 
 	return nil
 }
 
-// PokedexCommand lists all caught Pokemon
-func (app *App) PokedexCommand(args string) error {
-	fmt.Printf("\n=== Your Pokedex (%d Pokemon) ===\n", app.pokedex.Count())
-	app.pokedex.ListAll()
+// ReleaseCommand releases a caught Pokemon back into the wild
+func (app *App) ReleaseCommand(ctx context.Context, ref string) error {
+	c, err := app.findCaught(ref)
+	if err != nil {
+		return err
+	}
+
+	app.pokedex.Release(c.ID)
+	app.autoSave()
+	fmt.Printf("%s was released. Bye, %s!\n", c.Label(), c.DisplayName())
+
+	return nil
+}
+
+// NicknameCommand sets or clears the nickname of a caught Pokemon
+func (app *App) NicknameCommand(ctx context.Context, args string) error {
+	fields := strings.Fields(args)
+	if len(fields) == 0 || len(fields) > 2 {
+		return fmt.Errorf("usage: nickname <pokemon> [nickname]")
+	}
+
+	c, err := app.findCaught(fields[0])
+	if err != nil {
+		return err
+	}
+
+	var nickname string
+	if len(fields) == 2 {
+		nickname = fields[1]
+	}
+	if err := app.pokedex.Nickname(c.ID, nickname); err != nil {
+		return err
+	}
+	app.autoSave()
+
+	if nickname == "" {
+		fmt.Printf("Cleared the nickname of #%d %s.\n", c.ID, c.Name)
+	} else {
+		fmt.Printf("#%d %s is now called %s.\n", c.ID, c.Name, nickname)
+	}
+
+	return nil
+}
+
+// AbilityCommand describes an ability and which Pokemon can have it
+func (app *App) AbilityCommand(ctx context.Context, abilityName string) error {
+	a, err := app.client.GetAbility(ctx, abilityName)
+	if errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("there is no ability called %s", abilityName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch ability %s: %w", abilityName, err)
+	}
+
+	fmt.Printf("\n=== %s ===\n", a.Name)
+	if a.Effect != "" {
+		fmt.Println(a.Effect)
+	}
+
+	fmt.Printf("\nPokemon with %s:\n", a.Name)
+	for _, holder := range a.Holders {
+		line := "  - " + holder.Pokemon
+		if holder.IsHidden {
+			line += " (hidden)"
+		}
+		if app.pokedex.HasCaught(holder.Pokemon) {
+			line += " [caught]"
+		}
+		fmt.Println(line)
+	}
 	fmt.Println()
 
 	return nil
 }
+
+// CacheCommand inspects and maintains the API response cache
+func (app *App) CacheCommand(ctx context.Context, subcommand string) error {
+	c := app.client.Cache()
+	inspectable, ok := c.(cache.InspectableStore)
+
+	switch subcommand {
+	case "stats":
+		if !ok {
+			fmt.Printf("Entries: %d\n", c.Size())
+			return nil
+		}
+		stats := inspectable.Stats()
+		fmt.Println("\n=== Cache Stats ===")
+		fmt.Printf("Entries: %d\n", stats.Entries)
+		fmt.Printf("Bytes: %d\n", stats.Bytes)
+		fmt.Printf("Hits: %d\n", stats.Hits)
+		fmt.Printf("Misses: %d\n", stats.Misses)
+		fmt.Printf("Hit rate: %.2f\n", stats.HitRate())
+		fmt.Printf("Expirations: %d\n", stats.Expirations)
+		fmt.Printf("Evictions: %d\n", stats.Evictions)
+		fmt.Println()
+	case "clear":
+		c.Clear()
+		fmt.Println("Cache cleared.")
+	case "list":
+		if !ok {
+			return fmt.Errorf("this cache backend cannot list its entries")
+		}
+		entries := inspectable.Entries()
+		if len(entries) == 0 {
+			fmt.Println("The cache is empty.")
+			return nil
+		}
+		fmt.Println("Cached entries:")
+		for _, e := range entries {
+			fmt.Printf("  - %s (age: %s, %d bytes)\n", e.Key, e.Age.Round(time.Second), e.Size)
+		}
+	case "purge-expired":
+		if !ok {
+			return fmt.Errorf("this cache backend does not track expiry")
+		}
+		removed := inspectable.CleanExpired()
+		fmt.Printf("Removed %d expired entries.\n", removed)
+	default:
+		return fmt.Errorf("unknown cache subcommand '%s' (use stats, clear, list or purge-expired)", subcommand)
+	}
+
+	return nil
+}
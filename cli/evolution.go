@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mcoluomo/pokedexcli/api"
+	"github.com/mcoluomo/pokedexcli/pokemon"
+)
+
+// EvolutionsCommand shows the evolution chain of a Pokemon as a tree
+func (app *App) EvolutionsCommand(ctx context.Context, pokemonName string) error {
+	if pokemonName == "" {
+		return fmt.Errorf("please provide a Pokemon name")
+	}
+
+	chain, err := app.lookupEvolutionChain(ctx, pokemonName)
+	if errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("there is no Pokemon called %s", pokemonName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch evolutions of %s: %w", pokemonName, err)
+	}
+
+	fmt.Printf("\n=== Evolutions of %s ===\n", pokemonName)
+	fmt.Print(app.renderEvolutionTree(chain))
+	fmt.Println()
+
+	return nil
+}
+
+// EvolveCommand evolves a caught Pokemon if it meets the requirements
+func (app *App) EvolveCommand(ctx context.Context, args string) error {
+	fields := strings.Fields(args)
+	if len(fields) == 0 || len(fields) > 2 {
+		return fmt.Errorf("usage: evolve <pokemon> [item]")
+	}
+
+	evoCtx := pokemon.EvolutionContext{Time: time.Now()}
+	if len(fields) == 2 {
+		evoCtx.Item = fields[1]
+	}
+
+	c, err := app.findCaught(fields[0])
+	if err != nil {
+		return err
+	}
+	p := c.Pokemon
+
+	speciesName := p.SpeciesName
+	if speciesName == "" {
+		speciesName = p.Name
+	}
+
+	chain, err := app.client.GetEvolutionChain(ctx, speciesName)
+	if err != nil {
+		return fmt.Errorf("failed to fetch evolutions of %s: %w", p.Name, err)
+	}
+
+	stage, err := chain.NextEvolution(p, evoCtx)
+	if err != nil {
+		return err
+	}
+
+	next, err := app.lookupPokemon(ctx, stage.Species)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", stage.Species, err)
+	}
+
+	evolved := p.EvolveInto(next, evoCtx.Time)
+	app.pokedex.Update(c.ID, evolved)
+	app.pokedex.See(evolved.Name, evoCtx.Time)
+	app.autoSave()
+
+	fmt.Printf("What? %s is evolving!\n", c.DisplayName())
+	fmt.Printf("Congratulations! Your %s evolved into %s!\n", c.DisplayName(), evolved.Name)
+
+	return nil
+}
+
+// lookupEvolutionChain finds the chain for a Pokemon name, resolving it to
+// its species first when the two differ (e.g. alternate forms)
+func (app *App) lookupEvolutionChain(ctx context.Context, name string) (pokemon.EvolutionChain, error) {
+	chain, err := app.client.GetEvolutionChain(ctx, name)
+	if !errors.Is(err, api.ErrNotFound) {
+		return chain, err
+	}
+
+	p, err := app.client.GetPokemon(ctx, name)
+	if err != nil {
+		return pokemon.EvolutionChain{}, err
+	}
+	if p.SpeciesName == "" || p.SpeciesName == name {
+		return pokemon.EvolutionChain{}, api.ErrNotFound
+	}
+
+	return app.client.GetEvolutionChain(ctx, p.SpeciesName)
+}
+
+// renderEvolutionTree draws the chain as an ASCII tree, marking stages
+// that are in the Pokedex
+func (app *App) renderEvolutionTree(chain pokemon.EvolutionChain) string {
+	var b strings.Builder
+
+	var render func(stage *pokemon.EvolutionStage, prefix string, last bool, root bool)
+	render = func(stage *pokemon.EvolutionStage, prefix string, last bool, root bool) {
+		line := stage.Species
+		if !root {
+			connector := "|-- "
+			if last {
+				connector = "`-- "
+			}
+			line = prefix + connector + line
+		}
+
+		if len(stage.Requirements) > 0 {
+			reqs := make([]string, len(stage.Requirements))
+			for i, req := range stage.Requirements {
+				reqs[i] = req.String()
+			}
+			line += " (" + strings.Join(reqs, " or ") + ")"
+		}
+		if app.pokedex.HasCaught(stage.Species) {
+			line += " [caught]"
+		}
+		b.WriteString(line + "\n")
+
+		childPrefix := prefix
+		if !root {
+			if last {
+				childPrefix += "    "
+			} else {
+				childPrefix += "|   "
+			}
+		}
+		for i, next := range stage.EvolvesTo {
+			render(next, childPrefix, i == len(stage.EvolvesTo)-1, false)
+		}
+	}
+
+	if chain.Root != nil {
+		render(chain.Root, "", true, true)
+	}
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseArgs splits command arguments into positional arguments and
+// --name value / --name=value flags. Flags listed in boolFlags take no value
+// unless given as --name=true or --name=false; read them with boolFlag.
+func parseArgs(args string, boolFlags ...string) ([]string, map[string]string, error) {
+	isBool := make(map[string]bool, len(boolFlags))
+	for _, name := range boolFlags {
+		isBool[name] = true
+	}
+
+	var positional []string
+	flags := make(map[string]string)
+
+	fields := strings.Fields(args)
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		name, ok := strings.CutPrefix(field, "--")
+		if !ok {
+			positional = append(positional, field)
+			continue
+		}
+
+		if name, value, hasValue := strings.Cut(name, "="); hasValue {
+			if isBool[name] {
+				b, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, nil, fmt.Errorf("flag --%s takes true or false, not %q", name, value)
+				}
+				value = strconv.FormatBool(b)
+			}
+			flags[name] = value
+			continue
+		}
+
+		if isBool[name] {
+			flags[name] = "true"
+			continue
+		}
+
+		if i+1 >= len(fields) || strings.HasPrefix(fields[i+1], "--") {
+			return nil, nil, fmt.Errorf("flag --%s needs a value", name)
+		}
+		flags[name] = fields[i+1]
+		i++
+	}
+
+	return positional, flags, nil
+}
+
+// checkFlags rejects flags that a command does not understand
+func checkFlags(flags map[string]string, allowed ...string) error {
+	for name := range flags {
+		known := false
+		for _, a := range allowed {
+			if name == a {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown flag --%s", name)
+		}
+	}
+	return nil
+}
+
+// boolFlag reports whether a flag parsed as a bool flag is set to true
+func boolFlag(flags map[string]string, name string) bool {
+	return flags[name] == "true"
+}
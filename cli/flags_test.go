@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           string
+		wantPositional []string
+		wantFlags      map[string]string
+		wantErr        bool
+	}{
+		{
+			name:      "value after flag",
+			args:      "--sort bst",
+			wantFlags: map[string]string{"sort": "bst"},
+		},
+		{
+			name:      "value with equals",
+			args:      "--min-stat=attack=80",
+			wantFlags: map[string]string{"min-stat": "attack=80"},
+		},
+		{
+			name:           "positional and flags",
+			args:           "missing --dex kanto",
+			wantPositional: []string{"missing"},
+			wantFlags:      map[string]string{"dex": "kanto"},
+		},
+		{
+			name:      "bare bool flag",
+			args:      "--legendary --sort name",
+			wantFlags: map[string]string{"legendary": "true", "sort": "name"},
+		},
+		{
+			name:      "bool flag set to false",
+			args:      "--legendary=false",
+			wantFlags: map[string]string{"legendary": "false"},
+		},
+		{
+			name:      "bool flag spelled differently",
+			args:      "--legendary=1",
+			wantFlags: map[string]string{"legendary": "true"},
+		},
+		{
+			name:    "bool flag with a bad value",
+			args:    "--legendary=maybe",
+			wantErr: true,
+		},
+		{
+			name:    "missing value",
+			args:    "--sort",
+			wantErr: true,
+		},
+		{
+			name:    "flag where a value should be",
+			args:    "--sort --legendary",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			positional, flags, err := parseArgs(tt.args, "legendary")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(positional, tt.wantPositional) {
+				t.Errorf("positional = %q, want %q", positional, tt.wantPositional)
+			}
+			if !reflect.DeepEqual(flags, tt.wantFlags) {
+				t.Errorf("flags = %v, want %v", flags, tt.wantFlags)
+			}
+		})
+	}
+}
+
+func TestListOptionsLegendaryFalse(t *testing.T) {
+	for args, wantFilters := range map[string]int{
+		"--legendary":       1,
+		"--legendary=true":  1,
+		"--legendary=false": 0,
+	} {
+		_, flags, err := parseArgs(args, "legendary")
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts, err := parseListOptions(flags)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := len(opts.Filters); got != wantFilters {
+			t.Errorf("%s: %d filters, want %d", args, got, wantFilters)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mcoluomo/pokedexcli/pokemon"
+)
+
+// defaultHistoryWindow is how far back history looks without --since
+const defaultHistoryWindow = 7 * 24 * time.Hour
+
+// HistoryCommand lists catch attempts and explorations from the activity log
+func (app *App) HistoryCommand(ctx context.Context, args string) error {
+	positional, flags, err := parseArgs(args, "caught")
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return fmt.Errorf("usage: history [--since 7d] [--area name] [--caught]")
+	}
+	if err := checkFlags(flags, "since", "area", "caught"); err != nil {
+		return err
+	}
+
+	window := defaultHistoryWindow
+	if value, ok := flags["since"]; ok {
+		window, err = parseWindow(value)
+		if err != nil {
+			return err
+		}
+	}
+	since := time.Now().Add(-window)
+	area := flags["area"]
+	caughtOnly := boolFlag(flags, "caught")
+
+	attempts, err := app.repo.CatchAttempts(since)
+	if err != nil {
+		return err
+	}
+	explorations, err := app.repo.Explorations(since)
+	if err != nil {
+		return err
+	}
+
+	type event struct {
+		time time.Time
+		text string
+	}
+	var events []event
+	for _, a := range attempts {
+		if (area != "" && a.Location != area) || (caughtOnly && !a.Caught) {
+			continue
+		}
+		events = append(events, event{a.Time, describeAttempt(a)})
+	}
+	if !caughtOnly {
+		for _, e := range explorations {
+			if area != "" && e.Area != area {
+				continue
+			}
+			events = append(events, event{e.Time, fmt.Sprintf("explored %s, found %d Pokemon", e.Area, len(e.Pokemon))})
+		}
+	}
+
+	if len(events) == 0 {
+		fmt.Println("Nothing happened in that time.")
+		return nil
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].time.Before(events[j].time)
+	})
+
+	fmt.Printf("\n=== History (since %s) ===\n", since.Format(time.DateTime))
+	for _, e := range events {
+		fmt.Printf("%s  %s\n", e.time.Format(time.DateTime), e.text)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// describeAttempt summarises a catch attempt for the history listing
+func describeAttempt(a pokemon.CatchAttempt) string {
+	text := fmt.Sprintf("%s escaped", a.Pokemon)
+	if a.Caught {
+		text = fmt.Sprintf("caught %s as #%d", a.Pokemon, a.CaughtID)
+	}
+	if a.Location != "" {
+		text += " in " + a.Location
+	}
+	return text + fmt.Sprintf(" (catch rate: %.2f)", a.Rate)
+}
+
+// parseWindow reads a duration like 30m, 12h or 7d
+func parseWindow(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid --since %q, use e.g. 30m, 12h or 7d", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid --since %q, use e.g. 30m, 12h or 7d", value)
+	}
+	return d, nil
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mcoluomo/pokedexcli/api"
+)
+
+// MatchupCommand shows the weaknesses, resistances and immunities of a
+// Pokemon or type
+func (app *App) MatchupCommand(ctx context.Context, name string) error {
+	defending := []string{name}
+	if !app.typeChart.IsType(name) {
+		p, err := app.client.GetPokemon(ctx, name)
+		if errors.Is(err, api.ErrNotFound) {
+			return fmt.Errorf("there is no Pokemon or type called %s", name)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch Pokemon %s: %w", name, err)
+		}
+		defending = p.Types
+	}
+
+	// Refresh the chart from PokeAPI, keeping the built-in data on failure
+	for _, t := range defending {
+		rel, err := app.client.GetType(ctx, t)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			fmt.Printf("Couldn't fetch type %s, using built-in type chart.\n", t)
+			continue
+		}
+		app.typeChart.Apply(rel)
+	}
+
+	m := app.typeChart.Matchup(defending)
+
+	fmt.Printf("\n=== Matchup for %s (%s) ===\n", name, strings.Join(defending, "/"))
+	printMatchupLine("4x weak to", m.With(4.0))
+	printMatchupLine("2x weak to", m.With(2.0))
+	printMatchupLine("Resists (0.5x)", m.With(0.5))
+	printMatchupLine("Resists (0.25x)", m.With(0.25))
+	printMatchupLine("Immune to", m.With(0.0))
+	fmt.Println()
+
+	return nil
+}
+
+// printMatchupLine prints a category of attacking types if there are any
+func printMatchupLine(label string, types []string) {
+	if len(types) == 0 {
+		return
+	}
+	fmt.Printf("%s: %s\n", label, strings.Join(types, ", "))
+}
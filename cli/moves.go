@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mcoluomo/pokedexcli/api"
+	"github.com/mcoluomo/pokedexcli/pokemon"
+)
+
+// moveFetchWorkers bounds how many move lookups run at once
+const moveFetchWorkers = 4
+
+// MovesCommand lists the moves a Pokemon can learn
+func (app *App) MovesCommand(ctx context.Context, args string) error {
+	positional, flags, err := parseArgs(args)
+	if err != nil {
+		return err
+	}
+	if err := checkFlags(flags, "method", "version"); err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return fmt.Errorf("usage: moves <pokemon> [--method level-up|machine|egg] [--version ...]")
+	}
+	name := positional[0]
+
+	p, err := app.client.GetPokemon(ctx, name)
+	if errors.Is(err, api.ErrNotFound) {
+		return fmt.Errorf("there is no Pokemon called %s", name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch Pokemon %s: %w", name, err)
+	}
+
+	version := flags["version"]
+	if version == "" {
+		// Default to the most complete version group
+		if groups := p.Learnset.VersionGroups(); len(groups) > 0 {
+			version = groups[0]
+		}
+	}
+
+	entries := p.Learnset.Filter(flags["method"], version).Sorted()
+	if len(entries) == 0 {
+		fmt.Printf("%s learns no moves matching those filters.\n", name)
+		return nil
+	}
+
+	moves, err := app.fetchMoves(ctx, entries)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n=== Moves of %s (%s) ===\n", name, version)
+	method := ""
+	for _, entry := range entries {
+		if entry.Method != method {
+			method = entry.Method
+			fmt.Printf("%s:\n", method)
+		}
+
+		level := "    "
+		if entry.Method == pokemon.LearnLevelUp {
+			level = fmt.Sprintf("L%-3d", entry.Level)
+		}
+
+		m := moves[entry.Move]
+		fmt.Printf("  %s %-14s %-9s %-8s power: %-4s accuracy: %-4s pp: %d\n",
+			level, m.Name, m.Type, m.DamageClass, formatMoveValue(m.Power), formatMoveValue(m.Accuracy), m.PP)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// fetchMoves looks up the details of every move in entries concurrently.
+// The first failure cancels the lookups still in progress and stops new ones.
+func (app *App) fetchMoves(ctx context.Context, entries pokemon.Learnset) (map[string]pokemon.Move, error) {
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	names := make(chan string)
+	go func() {
+		defer close(names)
+		seen := make(map[string]bool)
+		for _, entry := range entries {
+			if seen[entry.Move] {
+				continue
+			}
+			seen[entry.Move] = true
+			select {
+			case names <- entry.Move:
+			case <-fetchCtx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		mutex    sync.Mutex
+		wg       sync.WaitGroup
+		moves    = make(map[string]pokemon.Move)
+		firstErr error
+	)
+	for range moveFetchWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range names {
+				if fetchCtx.Err() != nil {
+					continue
+				}
+				m, err := app.client.GetMove(fetchCtx, name)
+
+				mutex.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("failed to fetch move %s: %w", name, err)
+					cancel()
+				} else if err == nil {
+					moves[name] = m
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return moves, nil
+}
+
+// formatMoveValue shows a dash for moves without power or accuracy
+func formatMoveValue(v int) string {
+	if v == 0 {
+		return "-"
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mcoluomo/pokedexcli/api"
+	"github.com/mcoluomo/pokedexcli/pokemon"
+)
+
+const pokedexUsage = "usage: pokedex [caught] [--sort name|caught|weight|height|bst|type] [--type fire] [--min-stat attack=80] [--legendary], " +
+	"or pokedex seen|missing|completion [--dex name]"
+
+// PokedexCommand lists caught or seen Pokemon, the species still missing
+// from a Pokedex, or how complete the Pokedex is
+func (app *App) PokedexCommand(ctx context.Context, args string) error {
+	positional, flags, err := parseArgs(args, "legendary")
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return fmt.Errorf(pokedexUsage)
+	}
+	view := "caught"
+	if len(positional) == 1 {
+		view = positional[0]
+	}
+
+	switch view {
+	case "caught":
+		if err := checkFlags(flags, "sort", "type", "min-stat", "legendary"); err != nil {
+			return err
+		}
+	case "seen":
+		if err := checkFlags(flags); err != nil {
+			return err
+		}
+	case "missing", "completion":
+		if err := checkFlags(flags, "dex"); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf(pokedexUsage)
+	}
+
+	switch view {
+	case "seen":
+		app.listSeen()
+		return nil
+	case "missing":
+		return app.listMissing(ctx, flags["dex"])
+	case "completion":
+		return app.showCompletion(ctx, flags["dex"])
+	}
+	return app.listCaught(flags)
+}
+
+// listCaught prints the caught Pokemon selected and ordered by flags
+func (app *App) listCaught(flags map[string]string) error {
+	opts, err := parseListOptions(flags)
+	if err != nil {
+		return err
+	}
+	caught := app.pokedex.ListAll(opts)
+
+	fmt.Printf("\n=== Your Pokedex (%d Pokemon) ===\n", app.pokedex.Count())
+	switch {
+	case app.pokedex.Count() == 0:
+		fmt.Println("You haven't caught any Pokemon yet!")
+	case len(caught) == 0:
+		fmt.Println("None of your Pokemon match those filters.")
+	default:
+		if len(opts.Filters) > 0 {
+			fmt.Printf("%d match:\n", len(caught))
+		} else {
+			fmt.Println("Your Pokemon:")
+		}
+		for _, c := range caught {
+			fmt.Printf("  - %s%s\n", c.Label(), sortDetail(c, opts.Sort))
+		}
+	}
+	fmt.Printf("Seen %d different Pokemon.\n", app.pokedex.SeenCount())
+	fmt.Println()
+
+	return nil
+}
+
+// parseListOptions turns the caught view's flags into list options
+func parseListOptions(flags map[string]string) (pokemon.ListOptions, error) {
+	var opts pokemon.ListOptions
+	if value, ok := flags["sort"]; ok {
+		key, err := pokemon.ParseSortKey(value)
+		if err != nil {
+			return opts, err
+		}
+		opts.Sort = key
+	}
+	if typeName, ok := flags["type"]; ok {
+		opts.Filters = append(opts.Filters, pokemon.OfType(typeName))
+	}
+	if expr, ok := flags["min-stat"]; ok {
+		filter, err := pokemon.ParseMinStats(expr)
+		if err != nil {
+			return opts, err
+		}
+		opts.Filters = append(opts.Filters, filter)
+	}
+	if boolFlag(flags, "legendary") {
+		opts.Filters = append(opts.Filters, pokemon.Legendary())
+	}
+	return opts, nil
+}
+
+// sortDetail shows the value a listing is sorted by, if it isn't already
+// part of the label
+func sortDetail(c pokemon.CaughtPokemon, key pokemon.SortKey) string {
+	switch key {
+	case pokemon.SortByCaught:
+		return " caught " + c.CaughtAt.Format(time.DateTime)
+	case pokemon.SortByWeight:
+		return fmt.Sprintf(" weight %d", c.Weight)
+	case pokemon.SortByHeight:
+		return fmt.Sprintf(" height %d", c.Height)
+	case pokemon.SortByBST:
+		return fmt.Sprintf(" bst %d", c.BaseStatTotal())
+	case pokemon.SortByType:
+		return " [" + strings.Join(c.Types, ", ") + "]"
+	}
+	return ""
+}
+
+// listSeen prints every Pokemon seen, marking the ones currently caught
+func (app *App) listSeen() {
+	sightings := app.pokedex.Sightings()
+	if len(sightings) == 0 {
+		fmt.Println("You haven't seen any Pokemon yet! Try 'explore'.")
+		return
+	}
+
+	fmt.Printf("\n=== Seen Pokemon (%d) ===\n", len(sightings))
+	for _, s := range sightings {
+		line := fmt.Sprintf("  - %s (first seen %s)", s.Name, s.FirstSeen.Format(time.DateTime))
+		if app.pokedex.HasCaught(s.Name) {
+			line += " [caught]"
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+}
+
+// listMissing prints the species of a Pokedex that aren't caught yet
+func (app *App) listMissing(ctx context.Context, dexName string) error {
+	if dexName == "" {
+		dexName = api.NationalDex
+	}
+
+	dex, err := app.lookupDex(ctx, dexName)
+	if err != nil {
+		return err
+	}
+
+	missing := app.pokedex.Missing(dex)
+	if len(missing) == 0 {
+		fmt.Printf("You've caught every Pokemon in the %s Pokedex!\n", dex.Name)
+		return nil
+	}
+
+	fmt.Printf("\n=== Missing from the %s Pokedex (%d of %d) ===\n", dex.Name, len(missing), len(dex.Species))
+	for _, name := range missing {
+		line := "  - " + name
+		if app.pokedex.HasSeen(name) {
+			line += " [seen]"
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// showCompletion prints how much of the national Pokedex, each generation
+// and optionally a regional Pokedex has been seen and caught
+func (app *App) showCompletion(ctx context.Context, dexName string) error {
+	national, err := app.lookupDex(ctx, api.NationalDex)
+	if err != nil {
+		return err
+	}
+
+	generations, err := app.client.GetGenerations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch generations: %w", err)
+	}
+	var byGeneration []pokemon.Completion
+	for _, name := range generations {
+		gen, err := app.client.GetGeneration(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", name, err)
+		}
+		byGeneration = append(byGeneration, app.pokedex.Completion(gen))
+	}
+
+	fmt.Println("\n=== Pokedex Completion ===")
+	printCompletion(app.pokedex.Completion(national))
+
+	fmt.Println("\nBy generation:")
+	for _, c := range byGeneration {
+		printCompletion(c)
+	}
+
+	if dexName != "" && dexName != api.NationalDex {
+		dex, err := app.lookupDex(ctx, dexName)
+		if err != nil {
+			return err
+		}
+		fmt.Println("\nRegional:")
+		printCompletion(app.pokedex.Completion(dex))
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// lookupDex fetches a Pokedex by name, explaining if there's no such Pokedex
+func (app *App) lookupDex(ctx context.Context, name string) (pokemon.Dex, error) {
+	dex, err := app.client.GetPokedex(ctx, name)
+	if errors.Is(err, api.ErrNotFound) {
+		return pokemon.Dex{}, fmt.Errorf("there is no Pokedex called %s", name)
+	}
+	if err != nil {
+		return pokemon.Dex{}, fmt.Errorf("failed to fetch the %s Pokedex: %w", name, err)
+	}
+	return dex, nil
+}
+
+// printCompletion prints one line of the completion table
+func printCompletion(c pokemon.Completion) {
+	fmt.Printf("  %-16s seen %4d/%-4d (%5.1f%%)  caught %4d/%-4d (%5.1f%%)\n",
+		c.Dex, c.Seen, c.Total, c.SeenPercent(), c.Caught, c.Total, c.CaughtPercent())
+}
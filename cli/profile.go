@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ProfileCommand creates, lists, switches between and deletes trainer
+// profiles, and changes the active profile's settings
+func (app *App) ProfileCommand(ctx context.Context, args string) error {
+	if app.profiles == nil {
+		return fmt.Errorf("profiles are unavailable because saving is disabled")
+	}
+
+	fields := strings.Fields(args)
+	subcommand, rest := fields[0], fields[1:]
+
+	switch subcommand {
+	case "list":
+		return app.listProfiles()
+	case "new":
+		if len(rest) != 1 {
+			return fmt.Errorf("usage: profile new <name>")
+		}
+		return app.newProfile(rest[0])
+	case "switch":
+		if len(rest) != 1 {
+			return fmt.Errorf("usage: profile switch <name>")
+		}
+		return app.switchProfile(rest[0])
+	case "delete":
+		if len(rest) != 1 {
+			return fmt.Errorf("usage: profile delete <name>")
+		}
+		return app.deleteProfile(rest[0])
+	case "set":
+		if len(rest) != 2 {
+			return fmt.Errorf("usage: profile set autosave on|off")
+		}
+		return app.setProfileSetting(rest[0], rest[1])
+	default:
+		return fmt.Errorf("unknown profile subcommand '%s' (use new, list, switch, delete or set)", subcommand)
+	}
+}
+
+// listProfiles prints every profile, marking the active one
+func (app *App) listProfiles() error {
+	profiles, err := app.profiles.List()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Profiles:")
+	for _, p := range profiles {
+		marker := " "
+		if p.Name == app.profile.Name {
+			marker = "*"
+		}
+		fmt.Printf(" %s %s (created %s)\n", marker, p.Name, p.CreatedAt.Format(time.DateOnly))
+	}
+	return nil
+}
+
+// newProfile creates an empty profile without switching to it
+func (app *App) newProfile(name string) error {
+	if _, err := app.profiles.Create(name, time.Now()); err != nil {
+		return err
+	}
+	fmt.Printf("Created profile %s. Use 'profile switch %s' to play as it.\n", name, name)
+	return nil
+}
+
+// switchProfile saves the current Pokedex and loads the named profile's
+func (app *App) switchProfile(name string) error {
+	if name == app.profile.Name {
+		fmt.Printf("You're already playing as %s.\n", name)
+		return nil
+	}
+
+	p, err := app.profiles.Get(name)
+	if err != nil {
+		return err
+	}
+
+	app.autoSave()
+	app.useProfile(p)
+
+	fmt.Printf("Switched to profile %s (%d Pokemon).\n", p.Name, app.pokedex.Count())
+	return nil
+}
+
+// deleteProfile removes a profile other than the active one
+func (app *App) deleteProfile(name string) error {
+	if name == app.profile.Name {
+		return fmt.Errorf("can't delete the active profile, switch to another one first")
+	}
+	if err := app.profiles.Delete(name); err != nil {
+		return err
+	}
+	fmt.Printf("Deleted profile %s.\n", name)
+	return nil
+}
+
+// setProfileSetting changes one of the active profile's settings
+func (app *App) setProfileSetting(key, value string) error {
+	if app.profile.Name == "" {
+		return fmt.Errorf("no profile is active")
+	}
+
+	p := app.profile
+	switch key {
+	case "autosave":
+		switch value {
+		case "on":
+			p.Settings.Autosave = true
+		case "off":
+			p.Settings.Autosave = false
+		default:
+			return fmt.Errorf("autosave must be on or off")
+		}
+	default:
+		return fmt.Errorf("unknown setting '%s' (use autosave)", key)
+	}
+
+	if err := app.profiles.SaveSettings(p); err != nil {
+		return err
+	}
+	app.profile = p
+
+	fmt.Printf("Set %s to %s for profile %s.\n", key, value, p.Name)
+	return nil
+}
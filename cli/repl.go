@@ -2,9 +2,13 @@ package cli
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"sync"
 )
 
 // REPL represents the Read-Eval-Print Loop
@@ -12,12 +16,15 @@ type REPL struct {
 	app      *App
 	commands map[string]Command
 	running  bool
+
+	mutex  sync.Mutex
+	cancel context.CancelFunc // cancels the command in progress, if any
 }
 
 // NewREPL creates a new REPL instance
-func NewREPL() *REPL {
+func NewREPL(cfg Config) *REPL {
 	return &REPL{
-		app:      NewApp(),
+		app:      NewApp(cfg),
 		commands: GetCommands(),
 		running:  true,
 	}
@@ -28,6 +35,12 @@ func (r *REPL) Start() {
 	fmt.Println("Welcome to the Pokedex!")
 	fmt.Println("Type 'help' for available commands.")
 
+	// Ctrl-C cancels the running command instead of killing the Pokedex
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+	go r.handleInterrupts(interrupts)
+
 	scanner := bufio.NewScanner(os.Stdin)
 
 	for r.running {
@@ -48,6 +61,24 @@ func (r *REPL) Start() {
 	if err := scanner.Err(); err != nil {
 		fmt.Printf("Error reading input: %v\n", err)
 	}
+
+	r.app.Close()
+}
+
+// handleInterrupts cancels the command in progress on each interrupt
+func (r *REPL) handleInterrupts(interrupts <-chan os.Signal) {
+	for range interrupts {
+		r.mutex.Lock()
+		cancel := r.cancel
+		r.mutex.Unlock()
+
+		if cancel != nil {
+			fmt.Println("\nCancelling...")
+			cancel()
+		} else {
+			fmt.Print("\nType 'exit' to quit.\nPokedex > ")
+		}
+	}
 }
 
 // processInput handles user input
@@ -65,18 +96,34 @@ func (r *REPL) processInput(input string) {
 	}
 
 	// Check if command requires an argument
-	var arg string
-	if cmd.RequiresArg {
-		if len(words) < 2 {
-			fmt.Printf("Error: %s requires an argument.\n", commandName)
-			fmt.Printf("Usage: %s <argument>\n", commandName)
-			return
-		}
-		arg = words[1]
+	arg := strings.Join(words[1:], " ")
+	if cmd.KeepCase {
+		arg = strings.Join(strings.Fields(input)[1:], " ")
+	}
+	if cmd.RequiresArg && arg == "" {
+		fmt.Printf("Error: %s requires an argument.\n", commandName)
+		fmt.Printf("Usage: %s <argument>\n", commandName)
+		return
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	r.mutex.Lock()
+	r.cancel = cancel
+	r.mutex.Unlock()
+
+	defer func() {
+		r.mutex.Lock()
+		r.cancel = nil
+		r.mutex.Unlock()
+		cancel()
+	}()
+
 	// Execute command
-	if err := cmd.Callback(r.app, arg); err != nil {
+	if err := cmd.Callback(r.app, ctx, arg); err != nil {
+		if errors.Is(err, context.Canceled) {
+			fmt.Println("Command cancelled.")
+			return
+		}
 		fmt.Printf("Error: %v\n", err)
 	}
 }
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mcoluomo/pokedexcli/pokemon"
+)
+
+// SaveCommand saves the Pokedex, or exports it as JSON to the given path
+func (app *App) SaveCommand(ctx context.Context, path string) error {
+	if path != "" {
+		if err := app.pokedex.Save(path, time.Now()); err != nil {
+			return err
+		}
+		fmt.Printf("Exported %d Pokemon to %s.\n", app.pokedex.Count(), path)
+		return nil
+	}
+
+	if err := app.repo.Save(app.pokedex); err != nil {
+		return err
+	}
+	app.canSave = true
+
+	fmt.Printf("Saved %d Pokemon.\n", app.pokedex.Count())
+	return nil
+}
+
+// LoadCommand reloads the saved Pokedex, or replaces it with one exported
+// as JSON to the given path
+func (app *App) LoadCommand(ctx context.Context, path string) error {
+	if path == "" {
+		pd, err := app.repo.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load your Pokedex: %w", err)
+		}
+		app.pokedex = pd
+		app.canSave = true
+
+		fmt.Printf("Loaded %d Pokemon.\n", pd.Count())
+		return nil
+	}
+
+	pd, err := pokemon.LoadPokedex(path)
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", path, err)
+	}
+	app.pokedex = pd
+	app.canSave = true
+	app.autoSave()
+
+	fmt.Printf("Imported %d Pokemon from %s.\n", pd.Count(), path)
+	return nil
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mcoluomo/pokedexcli/pokemon"
+)
+
+func TestNewAppImportsPreProfileSave(t *testing.T) {
+	dir := t.TempDir()
+	savePath := filepath.Join(dir, "save.json")
+
+	old := pokemon.NewPokedex()
+	old.Catch(pokemon.CaughtPokemon{Pokemon: pokemon.Pokemon{Name: "pikachu"}, CaughtAt: time.Now()})
+	old.Catch(pokemon.CaughtPokemon{Pokemon: pokemon.Pokemon{Name: "eevee"}, CaughtAt: time.Now()})
+	if err := old.Save(savePath, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{ProfileDir: filepath.Join(dir, "profiles"), LegacySave: savePath}
+	app := NewApp(cfg)
+	if got := app.pokedex.Count(); got != 2 {
+		t.Errorf("Count() after import = %d, want 2", got)
+	}
+	app.Close()
+
+	if _, err := os.Stat(savePath); !os.IsNotExist(err) {
+		t.Errorf("save file still in place after import: %v", err)
+	}
+	if _, err := os.Stat(savePath + ".imported"); err != nil {
+		t.Errorf("save file wasn't renamed after import: %v", err)
+	}
+
+	// The import is kept by the profile, not repeated
+	reopened := NewApp(cfg)
+	defer reopened.Close()
+	if got := reopened.pokedex.Count(); got != 2 {
+		t.Errorf("Count() after reopening = %d, want 2", got)
+	}
+}
+
+func TestNewAppImportsPreProfileSaveOnlyIntoDefault(t *testing.T) {
+	dir := t.TempDir()
+	savePath := filepath.Join(dir, "save.json")
+
+	old := pokemon.NewPokedex()
+	old.Catch(pokemon.CaughtPokemon{Pokemon: pokemon.Pokemon{Name: "pikachu"}, CaughtAt: time.Now()})
+	if err := old.Save(savePath, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	app := NewApp(Config{ProfileDir: filepath.Join(dir, "profiles"), Profile: "ash", LegacySave: savePath})
+	defer app.Close()
+	if got := app.pokedex.Count(); got != 0 {
+		t.Errorf("Count() of another profile = %d, want 0", got)
+	}
+	if _, err := os.Stat(savePath); err != nil {
+		t.Errorf("save file was moved by another profile: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TrainCommand levels up a caught Pokemon, raising its friendship too
+func (app *App) TrainCommand(ctx context.Context, args string) error {
+	fields := strings.Fields(args)
+	if len(fields) == 0 || len(fields) > 2 {
+		return fmt.Errorf("usage: train <pokemon> [levels]")
+	}
+
+	levels := 1
+	if len(fields) == 2 {
+		n, err := strconv.Atoi(fields[1])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid number of levels %q", fields[1])
+		}
+		levels = n
+	}
+
+	c, err := app.findCaught(fields[0])
+	if err != nil {
+		return err
+	}
+
+	trained, err := c.LevelUp(levels, time.Now())
+	if err != nil {
+		return err
+	}
+	app.pokedex.Update(c.ID, trained)
+	app.autoSave()
+
+	fmt.Printf("%s grew to level %d! (friendship: %d)\n", c.DisplayName(), trained.Level, trained.Friendship)
+	fmt.Printf("Use 'evolve %d' to see if it is ready to evolve.\n", c.ID)
+
+	return nil
+}
+
+// GiveCommand gives a caught Pokemon an item to hold, or takes its item
+// away when no item is named
+func (app *App) GiveCommand(ctx context.Context, args string) error {
+	fields := strings.Fields(args)
+	if len(fields) == 0 || len(fields) > 2 {
+		return fmt.Errorf("usage: give <pokemon> [item]")
+	}
+
+	c, err := app.findCaught(fields[0])
+	if err != nil {
+		return err
+	}
+
+	var item string
+	if len(fields) == 2 {
+		item = fields[1]
+	}
+	if item == "" && c.HeldItem == "" {
+		return fmt.Errorf("%s isn't holding anything", c.DisplayName())
+	}
+
+	app.pokedex.Update(c.ID, c.Hold(item, time.Now()))
+	app.autoSave()
+
+	switch {
+	case item == "":
+		fmt.Printf("Took the %s from %s.\n", c.HeldItem, c.DisplayName())
+	case c.HeldItem != "":
+		fmt.Printf("%s is now holding the %s instead of the %s.\n", c.DisplayName(), item, c.HeldItem)
+	default:
+		fmt.Printf("%s is now holding the %s.\n", c.DisplayName(), item)
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package fakeapi
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// upstreamBaseURL is rewritten to the fake server's own URL in fixtures
+const upstreamBaseURL = "https://pokeapi.co/api/v2"
+
+// defaultLimit matches PokeAPI's default page size
+const defaultLimit = 20
+
+//go:embed fixtures
+var fixtures embed.FS
+
+// resource is a single fixture document
+type resource struct {
+	id   int
+	name string
+	data []byte
+}
+
+// Server is a local stand-in for PokeAPI backed by embedded fixture JSON.
+// Fixtures live under fixtures/<endpoint>/<name>.json and are served at
+// /api/v2/<endpoint>/<name or id>, with paginated listings at /api/v2/<endpoint>/.
+type Server struct {
+	*httptest.Server
+	resources map[string][]resource // endpoint -> resources sorted by id
+}
+
+// NewServer loads the fixtures and starts the fake PokeAPI
+func NewServer() (*Server, error) {
+	s := &Server{
+		resources: make(map[string][]resource),
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	for _, resources := range s.resources {
+		sort.Slice(resources, func(i, j int) bool {
+			return resources[i].id < resources[j].id
+		})
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s, nil
+}
+
+// BaseURL returns the API root to hand to api.WithBaseURL
+func (s *Server) BaseURL() string {
+	return s.URL + "/api/v2"
+}
+
+// load indexes every fixture by endpoint
+func (s *Server) load() error {
+	return fs.WalkDir(fixtures, "fixtures", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path.Ext(p) != ".json" {
+			return nil
+		}
+
+		data, err := fixtures.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		var header struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(data, &header); err != nil {
+			return fmt.Errorf("invalid fixture %s: %w", p, err)
+		}
+
+		endpoint := path.Base(path.Dir(p))
+		name := header.Name
+		if name == "" {
+			name = strings.TrimSuffix(path.Base(p), ".json")
+		}
+
+		s.resources[endpoint] = append(s.resources[endpoint], resource{
+			id:   header.ID,
+			name: name,
+			data: data,
+		})
+		return nil
+	})
+}
+
+// handle routes /api/v2/<endpoint>/[<name or id>]
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	rest, ok := strings.CutPrefix(r.URL.Path, "/api/v2/")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	endpoint, key, _ := strings.Cut(strings.Trim(rest, "/"), "/")
+	resources, ok := s.resources[endpoint]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if key == "" {
+		s.writeList(w, r, endpoint, resources)
+		return
+	}
+
+	for _, res := range resources {
+		if res.name == key || strconv.Itoa(res.id) == key {
+			s.write(w, r, s.rewrite(res.data))
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+// writeList renders a paginated NamedAPIResourceList
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, endpoint string, resources []resource) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultLimit
+	}
+	offset = max(0, min(offset, len(resources)))
+	end := min(offset+limit, len(resources))
+
+	type named struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	}
+	list := struct {
+		Count    int     `json:"count"`
+		Next     *string `json:"next"`
+		Previous *string `json:"previous"`
+		Results  []named `json:"results"`
+	}{
+		Count:   len(resources),
+		Results: make([]named, 0, end-offset),
+	}
+
+	pageURL := func(offset int) *string {
+		u := fmt.Sprintf("%s/%s/?offset=%d&limit=%d", s.BaseURL(), endpoint, offset, limit)
+		return &u
+	}
+	if end < len(resources) {
+		list.Next = pageURL(end)
+	}
+	if offset > 0 {
+		list.Previous = pageURL(max(0, offset-limit))
+	}
+
+	for _, res := range resources[offset:end] {
+		list.Results = append(list.Results, named{
+			Name: res.name,
+			URL:  fmt.Sprintf("%s/%s/%d/", s.BaseURL(), endpoint, res.id),
+		})
+	}
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	s.write(w, r, data)
+}
+
+// write sends data with an ETag, answering conditional requests with 304
+func (s *Server) write(w http.ResponseWriter, r *http.Request, data []byte) {
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(data)
+}
+
+// rewrite points upstream URLs inside a fixture at this server
+func (s *Server) rewrite(data []byte) []byte {
+	return []byte(strings.ReplaceAll(string(data), upstreamBaseURL, s.BaseURL()))
+}
@@ -1,8 +1,92 @@
 package main
 
-import "github.com/mcoluomo/pokedexcli/cli"
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mcoluomo/pokedexcli/cli"
+	"github.com/mcoluomo/pokedexcli/fakeapi"
+	"github.com/mcoluomo/pokedexcli/profile"
+)
 
 func main() {
-	repl := cli.NewREPL()
+	baseURL := flag.String("api-url", os.Getenv("POKEDEX_API_URL"), "PokeAPI base URL (env POKEDEX_API_URL)")
+	offline := flag.Bool("offline", false, "serve PokeAPI from built-in fixtures instead of the network")
+	profileName := flag.String("profile", profile.DefaultName, "trainer profile to play as; created if it doesn't exist")
+	profileDir := flag.String("profile-dir", defaultProfileDir(), "directory profiles are saved in; empty disables saving")
+	savePath := flag.String("save", defaultSavePath(), "save file from before profiles; imported into the default profile")
+	flag.Parse()
+
+	cfg := cli.Config{
+		BaseURL:    *baseURL,
+		ProfileDir: *profileDir,
+		Profile:    *profileName,
+		LegacySave: *savePath,
+	}
+
+	if *offline {
+		server, err := fakeapi.NewServer()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to start offline API: %v\n", err)
+			os.Exit(1)
+		}
+		defer server.Close()
+		cfg.BaseURL = server.BaseURL()
+
+		// Fixture responses live at a random port and the fixtures only cover
+		// a few Pokemon, so keep them out of the real cache and profiles
+		cfg.NoDiskCache = true
+		if !flagSet("profile-dir") {
+			cfg.ProfileDir = offlineProfileDir()
+		}
+		if !flagSet("save") {
+			cfg.LegacySave = ""
+		}
+	}
+
+	repl := cli.NewREPL(cfg)
 	repl.Start()
 }
+
+// defaultProfileDir returns the per-user profile directory, or "" if there
+// is no config dir to put it in
+func defaultProfileDir() string {
+	dir, err := profile.DefaultRoot()
+	if err != nil {
+		return ""
+	}
+	return dir
+}
+
+// defaultSavePath returns the save file from before profiles, or "" if
+// there is no config dir
+func defaultSavePath() string {
+	path, err := profile.PreProfileSavePath()
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// offlineProfileDir returns where profiles are kept when playing offline,
+// next to the regular profiles, or "" if there is no config dir
+func offlineProfileDir() string {
+	dir, err := profile.DefaultRoot()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(dir), "offline-profiles")
+}
+
+// flagSet reports whether a flag was given on the command line
+func flagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
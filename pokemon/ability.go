@@ -0,0 +1,23 @@
+package pokemon
+
+// PokemonAbility is an ability a Pokemon can have
+type PokemonAbility struct {
+	Name     string
+	IsHidden bool
+	Slot     int
+	Effect   string // short effect text, empty until fetched
+}
+
+// Ability describes an ability and the Pokemon that can have it
+type Ability struct {
+	Name        string
+	Effect      string
+	ShortEffect string
+	Holders     []AbilityHolder
+}
+
+// AbilityHolder is a Pokemon that can have an ability
+type AbilityHolder struct {
+	Pokemon  string
+	IsHidden bool
+}
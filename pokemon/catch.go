@@ -48,13 +48,40 @@ func (cs *CatchService) AttemptCatch(p Pokemon) (bool, float64) {
 	return caught, catchRate
 }
 
+// Capture prepares a freshly caught Pokemon: it rolls the level it was
+// found at, sets its starting friendship and records the catch. The
+// Pokedex assigns the ID when the result is added to it.
+func (cs *CatchService) Capture(p Pokemon, at time.Time, location string) CaughtPokemon {
+	if p.IsLegendary() || p.IsMythical() {
+		p.Level = 70
+	} else {
+		p.Level = 2 + cs.rng.Intn(29) // wild Pokemon are level 2-30
+	}
+	p.Friendship = BaseFriendship
+
+	event := "caught"
+	if location != "" {
+		event += " in " + location
+	}
+	p.History = append(p.History, HistoryEntry{
+		Time:  at,
+		Event: event,
+	})
+
+	return CaughtPokemon{
+		Pokemon:  p,
+		CaughtAt: at,
+		Location: location,
+	}
+}
+
 // calculateBonus applies various bonuses to catch rate
 func (cs *CatchService) calculateBonus(p Pokemon) float64 {
 	// Base bonus between 0.5 and 0.8
 	bonus := 0.5 + cs.rng.Float64()*0.3
 
-	// Legendary Pokemon are harder to catch
-	if p.IsLegendary() {
+	// Legendary and mythical Pokemon are harder to catch
+	if p.IsLegendary() || p.IsMythical() {
 		bonus *= 0.5
 	}
 
@@ -75,7 +102,7 @@ func (cs *CatchService) GetCatchPreview(p Pokemon) float64 {
 	difficulty := p.CatchDifficulty()
 	avgBonus := 0.65 // Average bonus
 
-	if p.IsLegendary() {
+	if p.IsLegendary() || p.IsMythical() {
 		avgBonus *= 0.5
 	}
 
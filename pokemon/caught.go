@@ -0,0 +1,53 @@
+package pokemon
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CaughtPokemon is one Pokemon owned by the trainer. Several can share a
+// species; each is told apart by its ID or nickname.
+type CaughtPokemon struct {
+	Pokemon
+	ID       int
+	Nickname string
+	CaughtAt time.Time
+	Location string // area it was caught in; empty if not caught while exploring
+}
+
+// DisplayName returns the nickname if there is one, otherwise the species
+func (c CaughtPokemon) DisplayName() string {
+	if c.Nickname != "" {
+		return c.Nickname
+	}
+	return c.Name
+}
+
+// Label identifies the instance in listings, e.g. `#3 pikachu "sparky" (lv 12)`
+func (c CaughtPokemon) Label() string {
+	label := fmt.Sprintf("#%d %s", c.ID, c.Name)
+	if c.Nickname != "" {
+		label += fmt.Sprintf(" %q", c.Nickname)
+	}
+	if c.Level > 0 {
+		label += fmt.Sprintf(" (lv %d)", c.Level)
+	}
+	return label
+}
+
+// String describes the instance followed by the Pokemon's details
+func (c CaughtPokemon) String() string {
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("ID: %d\n", c.ID))
+	if c.Nickname != "" {
+		result.WriteString(fmt.Sprintf("Nickname: %s\n", c.Nickname))
+	}
+	result.WriteString(fmt.Sprintf("Caught: %s", c.CaughtAt.Format(time.DateTime)))
+	if c.Location != "" {
+		result.WriteString(" in " + c.Location)
+	}
+	result.WriteString("\n")
+	result.WriteString(c.Pokemon.String())
+	return result.String()
+}
@@ -0,0 +1,74 @@
+package pokemon
+
+// Dex is a list of species to complete, such as a regional Pokedex or the
+// species introduced in one generation
+type Dex struct {
+	Name    string
+	Species []string // in dex order
+}
+
+// Completion summarises how much of a Dex has been seen and caught
+type Completion struct {
+	Dex    string
+	Total  int
+	Seen   int
+	Caught int
+}
+
+// SeenPercent returns the share of the Dex seen, from 0 to 100
+func (c Completion) SeenPercent() float64 {
+	return percent(c.Seen, c.Total)
+}
+
+// CaughtPercent returns the share of the Dex caught, from 0 to 100
+func (c Completion) CaughtPercent() float64 {
+	return percent(c.Caught, c.Total)
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 0.0
+	}
+	return 100.0 * float64(n) / float64(total)
+}
+
+// Completion counts the species of d that have been seen and that are
+// currently caught
+func (pd *Pokedex) Completion(d Dex) Completion {
+	caught := pd.caughtSpecies()
+	result := Completion{Dex: d.Name, Total: len(d.Species)}
+	for _, species := range d.Species {
+		if caught[species] {
+			result.Caught++
+		}
+		if caught[species] || pd.HasSeen(species) {
+			result.Seen++
+		}
+	}
+	return result
+}
+
+// Missing returns the species of d that aren't currently caught, in dex order
+func (pd *Pokedex) Missing(d Dex) []string {
+	caught := pd.caughtSpecies()
+	var missing []string
+	for _, species := range d.Species {
+		if !caught[species] {
+			missing = append(missing, species)
+		}
+	}
+	return missing
+}
+
+// caughtSpecies returns the names of every caught Pokemon and its species,
+// so alternate forms count towards their species
+func (pd *Pokedex) caughtSpecies() map[string]bool {
+	species := make(map[string]bool, len(pd.caught))
+	for _, c := range pd.caught {
+		species[c.Name] = true
+		if c.SpeciesName != "" {
+			species[c.SpeciesName] = true
+		}
+	}
+	return species
+}
@@ -0,0 +1,296 @@
+package pokemon
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Evolution triggers as named by PokeAPI
+const (
+	TriggerLevelUp = "level-up"
+	TriggerUseItem = "use-item"
+	TriggerTrade   = "trade"
+)
+
+// Ways a Pokemon's Attack can compare to its Defense, for evolutions that
+// depend on it
+const (
+	AttackHigher  = "attack>defense"
+	AttackEqual   = "attack=defense"
+	DefenseHigher = "attack<defense"
+)
+
+// spacedComparison spells out a PhysicalStats value, e.g. "attack > defense"
+var spacedComparison = strings.NewReplacer(">", " > ", "<", " < ", "=", " = ")
+
+// EvolutionRequirement describes one way a stage can be reached from its
+// parent. Conditions that don't apply are left zero.
+type EvolutionRequirement struct {
+	Trigger        string
+	MinLevel       int
+	Item           string // item used on the Pokemon, e.g. thunder-stone
+	HeldItem       string // item held while levelling up or trading
+	MinHappiness   int    // friendship needed when levelling up
+	MinAffection   int
+	MinBeauty      int
+	TimeOfDay      string
+	Location       string // where the level-up has to happen
+	KnownMove      string // move the Pokemon must know
+	KnownMoveType  string // type of a move the Pokemon must know
+	PartySpecies   string // species that must be in the party
+	PartyType      string // type of a Pokemon that must be in the party
+	TradeSpecies   string // species it must be traded for
+	PhysicalStats  string // AttackHigher, AttackEqual or DefenseHigher
+	Gender         string // female or male
+	NeedsRain      bool   // it must be raining where the Pokemon levels up
+	TurnUpsideDown bool   // the console must be held upside down
+}
+
+// String summarises the requirement, e.g. "level 16" or "use thunder-stone"
+func (r EvolutionRequirement) String() string {
+	var parts []string
+
+	switch r.Trigger {
+	case TriggerLevelUp:
+		if r.MinLevel > 0 {
+			parts = append(parts, fmt.Sprintf("level %d", r.MinLevel))
+		} else {
+			parts = append(parts, "level up")
+		}
+	case TriggerUseItem:
+		parts = append(parts, "use "+r.Item)
+	case TriggerTrade:
+		parts = append(parts, "trade")
+	default:
+		parts = append(parts, r.Trigger)
+	}
+
+	if r.MinHappiness > 0 {
+		parts = append(parts, fmt.Sprintf("friendship %d", r.MinHappiness))
+	}
+	if r.MinAffection > 0 {
+		parts = append(parts, fmt.Sprintf("affection %d", r.MinAffection))
+	}
+	if r.MinBeauty > 0 {
+		parts = append(parts, fmt.Sprintf("beauty %d", r.MinBeauty))
+	}
+	if r.HeldItem != "" {
+		parts = append(parts, "holding "+r.HeldItem)
+	}
+	if r.KnownMove != "" {
+		parts = append(parts, "knowing "+r.KnownMove)
+	}
+	if r.KnownMoveType != "" {
+		parts = append(parts, "knowing a "+r.KnownMoveType+" move")
+	}
+	if r.TradeSpecies != "" {
+		parts = append(parts, "for "+r.TradeSpecies)
+	}
+	if r.PartySpecies != "" {
+		parts = append(parts, "with "+r.PartySpecies+" in the party")
+	}
+	if r.PartyType != "" {
+		parts = append(parts, "with a "+r.PartyType+" type in the party")
+	}
+	if r.PhysicalStats != "" {
+		parts = append(parts, "with "+spacedComparison.Replace(r.PhysicalStats))
+	}
+	if r.Gender != "" {
+		parts = append(parts, r.Gender+" only")
+	}
+	if r.Location != "" {
+		parts = append(parts, "at "+r.Location)
+	}
+	if r.NeedsRain {
+		parts = append(parts, "in the rain")
+	}
+	if r.TimeOfDay != "" {
+		parts = append(parts, "during the "+r.TimeOfDay)
+	}
+	if r.TurnUpsideDown {
+		parts = append(parts, "upside down")
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// EvolutionStage is a species within an evolution chain
+type EvolutionStage struct {
+	Species      string
+	Requirements []EvolutionRequirement // how to reach this stage; empty for the base stage
+	EvolvesTo    []*EvolutionStage
+}
+
+// EvolutionChain is the tree of stages a family of Pokemon evolves through
+type EvolutionChain struct {
+	ID   int
+	Root *EvolutionStage
+}
+
+// Find returns the stage for a species, or nil if it is not in the chain
+func (c EvolutionChain) Find(species string) *EvolutionStage {
+	var found *EvolutionStage
+	c.Walk(func(stage *EvolutionStage, depth int) {
+		if found == nil && stage.Species == species {
+			found = stage
+		}
+	})
+	return found
+}
+
+// Walk visits every stage depth first, starting at the root with depth 0
+func (c EvolutionChain) Walk(visit func(stage *EvolutionStage, depth int)) {
+	var walk func(stage *EvolutionStage, depth int)
+	walk = func(stage *EvolutionStage, depth int) {
+		if stage == nil {
+			return
+		}
+		visit(stage, depth)
+		for _, next := range stage.EvolvesTo {
+			walk(next, depth+1)
+		}
+	}
+	walk(c.Root, 0)
+}
+
+// ErrUnsupportedRequirement is returned by Check for evolution conditions
+// that can't be evaluated
+var ErrUnsupportedRequirement = errors.New("unsupported requirement")
+
+// EvolutionContext is the situation an evolution is attempted in
+type EvolutionContext struct {
+	Item string // item being used on the Pokemon, if any
+	Time time.Time
+}
+
+// Check reports whether p meets the requirement, explaining why not if it
+// doesn't. Conditions that can't be evaluated here, such as where the
+// Pokemon is or which moves it knows, fail with ErrUnsupportedRequirement.
+func (r EvolutionRequirement) Check(p Pokemon, ctx EvolutionContext) error {
+	if need := r.unsupported(); need != "" {
+		return fmt.Errorf("needs %s: %w", need, ErrUnsupportedRequirement)
+	}
+
+	switch r.Trigger {
+	case TriggerLevelUp:
+		if p.Level < r.MinLevel {
+			return fmt.Errorf("needs level %d (is level %d)", r.MinLevel, p.Level)
+		}
+	case TriggerUseItem:
+		if ctx.Item != r.Item {
+			return fmt.Errorf("needs a %s", r.Item)
+		}
+	}
+
+	if p.Friendship < r.MinHappiness {
+		return fmt.Errorf("needs friendship %d (has %d)", r.MinHappiness, p.Friendship)
+	}
+	if r.HeldItem != "" && p.HeldItem != r.HeldItem {
+		return fmt.Errorf("needs to hold a %s", r.HeldItem)
+	}
+	if r.TimeOfDay != "" && timeOfDay(ctx.Time) != r.TimeOfDay {
+		return fmt.Errorf("needs to be %s", r.TimeOfDay)
+	}
+	if r.PhysicalStats != "" && physicalStats(p) != r.PhysicalStats {
+		return fmt.Errorf("needs %s", spacedComparison.Replace(r.PhysicalStats))
+	}
+
+	return nil
+}
+
+// unsupported describes the first condition of the requirement that can't
+// be evaluated, or returns "" if every condition can be
+func (r EvolutionRequirement) unsupported() string {
+	switch {
+	case r.Trigger == TriggerTrade || r.TradeSpecies != "":
+		return "to be traded"
+	case r.Trigger != TriggerLevelUp && r.Trigger != TriggerUseItem:
+		return r.Trigger
+	case r.MinAffection > 0:
+		return fmt.Sprintf("affection %d", r.MinAffection)
+	case r.MinBeauty > 0:
+		return fmt.Sprintf("beauty %d", r.MinBeauty)
+	case r.Location != "":
+		return "to be at " + r.Location
+	case r.KnownMove != "":
+		return "to know " + r.KnownMove
+	case r.KnownMoveType != "":
+		return "to know a " + r.KnownMoveType + " move"
+	case r.PartySpecies != "":
+		return r.PartySpecies + " in the party"
+	case r.PartyType != "":
+		return "a " + r.PartyType + " type in the party"
+	case r.Gender != "":
+		return "to be " + r.Gender
+	case r.NeedsRain:
+		return "rain"
+	case r.TurnUpsideDown:
+		return "to be turned upside down"
+	}
+	return ""
+}
+
+// physicalStats compares p's Attack to its Defense
+func physicalStats(p Pokemon) string {
+	switch attack, defense := p.Stats["attack"], p.Stats["defense"]; {
+	case attack > defense:
+		return AttackHigher
+	case attack < defense:
+		return DefenseHigher
+	default:
+		return AttackEqual
+	}
+}
+
+// timeOfDay buckets a time the way the games do for evolution purposes
+func timeOfDay(t time.Time) string {
+	if hour := t.Hour(); hour >= 6 && hour < 18 {
+		return "day"
+	}
+	return "night"
+}
+
+// NextEvolution returns the stage p can evolve into right now. If p cannot
+// evolve, the error lists what each possible evolution still needs.
+func (c EvolutionChain) NextEvolution(p Pokemon, ctx EvolutionContext) (*EvolutionStage, error) {
+	species := p.SpeciesName
+	if species == "" {
+		species = p.Name
+	}
+
+	current := c.Find(species)
+	if current == nil {
+		return nil, fmt.Errorf("%s is not part of this evolution chain", species)
+	}
+	if len(current.EvolvesTo) == 0 {
+		return nil, fmt.Errorf("%s does not evolve", species)
+	}
+
+	var reasons []string
+	for _, next := range current.EvolvesTo {
+		for _, req := range next.Requirements {
+			err := req.Check(p, ctx)
+			if err == nil {
+				return next, nil
+			}
+			reasons = append(reasons, fmt.Sprintf("%s %s", next.Species, err))
+		}
+	}
+
+	return nil, fmt.Errorf("%s can't evolve yet: %s", p.Name, strings.Join(reasons, "; "))
+}
+
+// EvolveInto returns next carrying over everything p has gained since it
+// was caught, with the evolution recorded in its history
+func (p Pokemon) EvolveInto(next Pokemon, at time.Time) Pokemon {
+	next.Level = p.Level
+	next.Friendship = p.Friendship
+	next.HeldItem = p.HeldItem
+	next.History = append(append([]HistoryEntry(nil), p.History...), HistoryEntry{
+		Time:  at,
+		Event: fmt.Sprintf("evolved from %s", p.Name),
+	})
+	return next
+}
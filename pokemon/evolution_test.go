@@ -0,0 +1,144 @@
+package pokemon
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// eeveeChain mirrors the shape of PokeAPI's Eevee chain: stones, friendship
+// by time of day, places and move types, several ways per stage
+func eeveeChain() EvolutionChain {
+	stone := func(item string) EvolutionRequirement {
+		return EvolutionRequirement{Trigger: TriggerUseItem, Item: item}
+	}
+	at := func(location string) EvolutionRequirement {
+		return EvolutionRequirement{Trigger: TriggerLevelUp, Location: location}
+	}
+	stage := func(species string, reqs ...EvolutionRequirement) *EvolutionStage {
+		return &EvolutionStage{Species: species, Requirements: reqs}
+	}
+
+	return EvolutionChain{ID: 67, Root: &EvolutionStage{
+		Species: "eevee",
+		EvolvesTo: []*EvolutionStage{
+			stage("vaporeon", stone("water-stone")),
+			stage("jolteon", stone("thunder-stone")),
+			stage("flareon", stone("fire-stone")),
+			stage("espeon", EvolutionRequirement{Trigger: TriggerLevelUp, MinHappiness: 160, TimeOfDay: "day"}),
+			stage("umbreon", EvolutionRequirement{Trigger: TriggerLevelUp, MinHappiness: 160, TimeOfDay: "night"}),
+			stage("leafeon", at("eterna-forest"), at("pinwheel-forest"), at("kalos-route-20"), stone("leaf-stone")),
+			stage("glaceon", at("sinnoh-route-217"), at("twist-mountain"), at("frost-cavern"), stone("ice-stone")),
+			stage("sylveon",
+				EvolutionRequirement{Trigger: TriggerLevelUp, MinAffection: 2, KnownMoveType: "fairy"},
+				EvolutionRequirement{Trigger: TriggerLevelUp, MinHappiness: 160, KnownMoveType: "fairy"}),
+		},
+	}}
+}
+
+func TestNextEvolutionEevee(t *testing.T) {
+	noon := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	midnight := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		friendship int
+		ctx        EvolutionContext
+		want       string // "" if Eevee can't evolve
+	}{
+		{"levelling up with no item", BaseFriendship, EvolutionContext{Time: noon}, ""},
+		{"water stone", BaseFriendship, EvolutionContext{Item: "water-stone", Time: noon}, "vaporeon"},
+		{"leaf stone", BaseFriendship, EvolutionContext{Item: "leaf-stone", Time: noon}, "leafeon"},
+		{"ice stone", BaseFriendship, EvolutionContext{Item: "ice-stone", Time: midnight}, "glaceon"},
+		{"friendly by day", MaxFriendship, EvolutionContext{Time: noon}, "espeon"},
+		{"friendly by night", MaxFriendship, EvolutionContext{Time: midnight}, "umbreon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Pokemon{Name: "eevee", Level: 30, Friendship: tt.friendship}
+			stage, err := eeveeChain().NextEvolution(p, tt.ctx)
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("NextEvolution() = %s, want an error", stage.Species)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NextEvolution() error = %v, want %s", err, tt.want)
+			}
+			if stage.Species != tt.want {
+				t.Errorf("NextEvolution() = %s, want %s", stage.Species, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckUnsupportedRequirements(t *testing.T) {
+	// A Pokemon that meets every condition Check can evaluate
+	p := Pokemon{
+		Name:       "eevee",
+		Level:      MaxLevel,
+		Friendship: MaxFriendship,
+		Stats:      map[string]int{"attack": 55, "defense": 50},
+	}
+	ctx := EvolutionContext{Time: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+
+	chain := eeveeChain()
+	var eeveeReqs []EvolutionRequirement
+	for _, species := range []string{"leafeon", "glaceon", "sylveon"} {
+		for _, req := range chain.Find(species).Requirements {
+			if req.Trigger == TriggerLevelUp {
+				eeveeReqs = append(eeveeReqs, req)
+			}
+		}
+	}
+
+	tests := append(eeveeReqs,
+		EvolutionRequirement{Trigger: TriggerTrade},
+		EvolutionRequirement{Trigger: "shed"},
+		EvolutionRequirement{Trigger: TriggerLevelUp, KnownMove: "ancient-power"},
+		EvolutionRequirement{Trigger: TriggerLevelUp, PartySpecies: "remoraid"},
+		EvolutionRequirement{Trigger: TriggerLevelUp, PartyType: "dark"},
+		EvolutionRequirement{Trigger: TriggerLevelUp, MinBeauty: 171},
+		EvolutionRequirement{Trigger: TriggerUseItem, Item: "dawn-stone", Gender: "female"},
+		EvolutionRequirement{Trigger: TriggerLevelUp, MinLevel: 50, NeedsRain: true},
+		EvolutionRequirement{Trigger: TriggerLevelUp, MinLevel: 30, TurnUpsideDown: true},
+	)
+
+	for _, req := range tests {
+		t.Run(req.String(), func(t *testing.T) {
+			ctx := ctx
+			ctx.Item = req.Item
+			if err := req.Check(p, ctx); !errors.Is(err, ErrUnsupportedRequirement) {
+				t.Errorf("Check() = %v, want %v", err, ErrUnsupportedRequirement)
+			}
+		})
+	}
+}
+
+func TestCheckPhysicalStats(t *testing.T) {
+	tyrogue := func(attack, defense int) Pokemon {
+		return Pokemon{Name: "tyrogue", Level: 20, Stats: map[string]int{"attack": attack, "defense": defense}}
+	}
+
+	tests := []struct {
+		stats string
+		p     Pokemon
+		ok    bool
+	}{
+		{AttackHigher, tyrogue(40, 35), true},
+		{AttackHigher, tyrogue(35, 40), false},
+		{DefenseHigher, tyrogue(35, 40), true},
+		{AttackEqual, tyrogue(35, 35), true},
+		{AttackEqual, tyrogue(40, 35), false},
+	}
+
+	for _, tt := range tests {
+		req := EvolutionRequirement{Trigger: TriggerLevelUp, MinLevel: 20, PhysicalStats: tt.stats}
+		err := req.Check(tt.p, EvolutionContext{})
+		if (err == nil) != tt.ok {
+			t.Errorf("Check(%s) with %v = %v, want ok %v", tt.stats, tt.p.Stats, err, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package pokemon
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Filter selects caught Pokemon for a Pokedex listing
+type Filter func(CaughtPokemon) bool
+
+// statAliases maps the short stat names accepted in filters to PokeAPI's
+// stat names. "bst" and "total" stand for the base stat total.
+var statAliases = map[string]string{
+	"hp":              "hp",
+	"atk":             "attack",
+	"attack":          "attack",
+	"def":             "defense",
+	"defense":         "defense",
+	"spa":             "special-attack",
+	"sp-atk":          "special-attack",
+	"special-attack":  "special-attack",
+	"spd":             "special-defense",
+	"sp-def":          "special-defense",
+	"special-defense": "special-defense",
+	"spe":             "speed",
+	"speed":           "speed",
+	"bst":             "bst",
+	"total":           "bst",
+}
+
+// OfType keeps Pokemon that have the given type
+func OfType(typeName string) Filter {
+	return func(c CaughtPokemon) bool {
+		for _, t := range c.Types {
+			if t == typeName {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Legendary keeps legendary and mythical Pokemon
+func Legendary() Filter {
+	return func(c CaughtPokemon) bool {
+		return c.IsLegendary() || c.IsMythical()
+	}
+}
+
+// MinStat keeps Pokemon whose stat is at least min. The stat "bst" is the
+// base stat total.
+func MinStat(stat string, min int) Filter {
+	return func(c CaughtPokemon) bool {
+		if stat == "bst" {
+			return c.BaseStatTotal() >= min
+		}
+		return c.Stats[stat] >= min
+	}
+}
+
+// ParseMinStats parses a comma-separated list of minimum stats such as
+// "attack=80" or "spe=100,bst=500" into a single filter
+func ParseMinStats(expr string) (Filter, error) {
+	var filters []Filter
+	for _, term := range strings.Split(expr, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(term), "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid stat filter %q, use e.g. attack=80", term)
+		}
+
+		stat, known := statAliases[strings.TrimSpace(name)]
+		if !known {
+			return nil, fmt.Errorf("unknown stat %q (use %s)", name, strings.Join(statNames(), ", "))
+		}
+
+		min, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || min < 0 {
+			return nil, fmt.Errorf("invalid minimum %q for %s", value, name)
+		}
+
+		filters = append(filters, MinStat(stat, min))
+	}
+
+	return func(c CaughtPokemon) bool {
+		return matchesAll(c, filters)
+	}, nil
+}
+
+// statNames returns the stat names filters accept, sorted
+func statNames() []string {
+	names := make([]string, 0, len(statAliases))
+	for name := range statAliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,103 @@
+package pokemon
+
+import "sort"
+
+// Move learn methods as named by PokeAPI
+const (
+	LearnLevelUp = "level-up"
+	LearnMachine = "machine"
+	LearnEgg     = "egg"
+	LearnTutor   = "tutor"
+)
+
+// Move represents an attack a Pokemon can know
+type Move struct {
+	Name        string
+	Type        string
+	Power       int // 0 for moves that deal no direct damage
+	Accuracy    int // 0 for moves that never miss
+	PP          int
+	DamageClass string // physical, special or status
+}
+
+// LearnsetEntry records how a Pokemon learns a move in one version group
+type LearnsetEntry struct {
+	Move         string
+	Method       string
+	Level        int // only meaningful for level-up moves
+	VersionGroup string
+}
+
+// Learnset is every way a Pokemon can learn its moves
+type Learnset []LearnsetEntry
+
+// Filter returns the entries matching method and version group; an empty
+// argument matches anything
+func (l Learnset) Filter(method, versionGroup string) Learnset {
+	result := make(Learnset, 0, len(l))
+	for _, entry := range l {
+		if method != "" && entry.Method != method {
+			continue
+		}
+		if versionGroup != "" && entry.VersionGroup != versionGroup {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// VersionGroups lists the version groups in the learnset, most complete first
+func (l Learnset) VersionGroups() []string {
+	counts := make(map[string]int)
+	for _, entry := range l {
+		counts[entry.VersionGroup]++
+	}
+
+	groups := make([]string, 0, len(counts))
+	for group := range counts {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if counts[groups[i]] != counts[groups[j]] {
+			return counts[groups[i]] > counts[groups[j]]
+		}
+		return groups[i] < groups[j]
+	})
+	return groups
+}
+
+// methodOrder ranks learn methods for display; unknown methods sort last
+var methodOrder = map[string]int{
+	LearnLevelUp: 0,
+	LearnMachine: 1,
+	LearnEgg:     2,
+	LearnTutor:   3,
+}
+
+// methodRank returns the display rank of a learn method
+func methodRank(method string) int {
+	if rank, ok := methodOrder[method]; ok {
+		return rank
+	}
+	return len(methodOrder)
+}
+
+// Sorted returns the entries ordered by method, then level, then move name
+func (l Learnset) Sorted() Learnset {
+	result := append(Learnset(nil), l...)
+	sort.SliceStable(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if methodRank(a.Method) != methodRank(b.Method) {
+			return methodRank(a.Method) < methodRank(b.Method)
+		}
+		if a.Method != b.Method {
+			return a.Method < b.Method
+		}
+		if a.Level != b.Level {
+			return a.Level < b.Level
+		}
+		return a.Move < b.Move
+	})
+	return result
+}
@@ -1,47 +1,241 @@
 package pokemon
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
 
-// Pokedex is our aggregate root for managing caught Pokemon
+// ErrNotCaught is returned when no caught Pokemon matches a reference
+var ErrNotCaught = errors.New("not caught")
+
+// Pokedex is our aggregate root for managing caught Pokemon and the
+// Pokemon the trainer has seen
 type Pokedex struct {
-	caught map[string]Pokemon
+	caught map[int]CaughtPokemon
+	seen   map[string]time.Time // Pokemon name -> when it was first seen
+	nextID int
+}
+
+// Sighting records when a Pokemon was first seen
+type Sighting struct {
+	Name      string
+	FirstSeen time.Time
 }
 
 // NewPokedex creates a new Pokedex instance
 func NewPokedex() *Pokedex {
 	return &Pokedex{
-		caught: make(map[string]Pokemon),
+		caught: make(map[int]CaughtPokemon),
+		seen:   make(map[string]time.Time),
+		nextID: 1,
 	}
 }
 
-// Catch adds a Pokemon to the Pokedex
-func (pd *Pokedex) Catch(p Pokemon) {
-	pd.caught[p.Name] = p
+// Catch adds a Pokemon to the Pokedex, assigning it a new ID. A caught
+// Pokemon counts as seen.
+func (pd *Pokedex) Catch(c CaughtPokemon) CaughtPokemon {
+	c.ID = pd.nextID
+	pd.nextID++
+	pd.caught[c.ID] = c
+	pd.See(c.Name, c.CaughtAt)
+	return c
 }
 
-// HasCaught checks if a Pokemon has been caught
-func (pd *Pokedex) HasCaught(name string) bool {
-	_, exists := pd.caught[name]
+// See records that a Pokemon was seen at the given time. It reports
+// whether this is the first time it was seen.
+func (pd *Pokedex) See(name string, at time.Time) bool {
+	if _, exists := pd.seen[name]; exists {
+		return false
+	}
+	pd.seen[name] = at
+	return true
+}
+
+// HasSeen checks if a Pokemon has ever been seen. Seen Pokemon stay seen
+// after they are released.
+func (pd *Pokedex) HasSeen(name string) bool {
+	_, exists := pd.seen[name]
 	return exists
 }
 
-// Get retrieves a caught Pokemon by name
-func (pd *Pokedex) Get(name string) (Pokemon, bool) {
-	p, exists := pd.caught[name]
-	return p, exists
+// Sightings returns every seen Pokemon ordered by name
+func (pd *Pokedex) Sightings() []Sighting {
+	sightings := make([]Sighting, 0, len(pd.seen))
+	for name, at := range pd.seen {
+		sightings = append(sightings, Sighting{Name: name, FirstSeen: at})
+	}
+	sort.Slice(sightings, func(i, j int) bool {
+		return sightings[i].Name < sightings[j].Name
+	})
+	return sightings
+}
+
+// SeenCount returns the number of different Pokemon seen
+func (pd *Pokedex) SeenCount() int {
+	return len(pd.seen)
+}
+
+// HasCaught checks if any Pokemon of the given name has been caught
+func (pd *Pokedex) HasCaught(name string) bool {
+	for _, c := range pd.caught {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Get retrieves a caught Pokemon by ID
+func (pd *Pokedex) Get(id int) (CaughtPokemon, bool) {
+	c, exists := pd.caught[id]
+	return c, exists
 }
 
-// ListAll displays all caught Pokemon
-func (pd *Pokedex) ListAll() {
-	if len(pd.caught) == 0 {
-		fmt.Println("You haven't caught any Pokemon yet!")
-		return
+// Find resolves a reference to a caught Pokemon. The reference may be an
+// ID, a nickname, or a Pokemon name if only one of that Pokemon is owned.
+// Names and nicknames match regardless of case.
+func (pd *Pokedex) Find(ref string) (CaughtPokemon, error) {
+	if id, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+		if c, exists := pd.caught[id]; exists {
+			return c, nil
+		}
+		return CaughtPokemon{}, fmt.Errorf("no Pokemon with ID %d: %w", id, ErrNotCaught)
+	}
+
+	for _, c := range pd.caught {
+		if c.Nickname != "" && strings.EqualFold(c.Nickname, ref) {
+			return c, nil
+		}
+	}
+
+	matches := pd.byName(strings.ToLower(ref))
+	switch len(matches) {
+	case 0:
+		return CaughtPokemon{}, fmt.Errorf("no Pokemon called %s: %w", ref, ErrNotCaught)
+	case 1:
+		return matches[0], nil
 	}
 
-	fmt.Println("Your Pokemon:")
-	for name := range pd.caught {
-		fmt.Printf("  - %s\n", name)
+	ids := make([]string, len(matches))
+	for i, c := range matches {
+		ids[i] = "#" + strconv.Itoa(c.ID)
 	}
+	return CaughtPokemon{}, fmt.Errorf("you have %d %s (%s), use an ID or nickname",
+		len(matches), ref, strings.Join(ids, ", "))
+}
+
+// byName returns every caught Pokemon of the given name, ordered by ID
+func (pd *Pokedex) byName(name string) []CaughtPokemon {
+	var matches []CaughtPokemon
+	for _, c := range pd.All() {
+		if c.Name == name {
+			matches = append(matches, c)
+		}
+	}
+	return matches
+}
+
+// All returns every caught Pokemon ordered by ID
+func (pd *Pokedex) All() []CaughtPokemon {
+	all := make([]CaughtPokemon, 0, len(pd.caught))
+	for _, c := range pd.caught {
+		all = append(all, c)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].ID < all[j].ID
+	})
+	return all
+}
+
+// SortKey orders a Pokedex listing
+type SortKey string
+
+// Supported sort keys. Names, catch times and types sort in ascending
+// order; weight, height and base stat total put the largest first.
+const (
+	SortByID     SortKey = "id"
+	SortByName   SortKey = "name"
+	SortByCaught SortKey = "caught"
+	SortByWeight SortKey = "weight"
+	SortByHeight SortKey = "height"
+	SortByBST    SortKey = "bst"
+	SortByType   SortKey = "type"
+)
+
+// ParseSortKey validates a sort key given by the user
+func ParseSortKey(s string) (SortKey, error) {
+	switch key := SortKey(s); key {
+	case SortByID, SortByName, SortByCaught, SortByWeight, SortByHeight, SortByBST, SortByType:
+		return key, nil
+	}
+	return "", fmt.Errorf("unknown sort %q (use id, name, caught, weight, height, bst or type)", s)
+}
+
+// ListOptions selects and orders the Pokemon returned by ListAll
+type ListOptions struct {
+	Sort    SortKey  // empty sorts by ID
+	Filters []Filter // a Pokemon is listed only if every filter keeps it
+}
+
+// ListAll returns the caught Pokemon matching opts, in the requested order.
+// Ties are broken by ID.
+func (pd *Pokedex) ListAll(opts ListOptions) []CaughtPokemon {
+	var result []CaughtPokemon
+	for _, c := range pd.All() {
+		if matchesAll(c, opts.Filters) {
+			result = append(result, c)
+		}
+	}
+
+	less := sortLess(opts.Sort)
+	sort.SliceStable(result, func(i, j int) bool {
+		return less(result[i], result[j])
+	})
+	return result
+}
+
+// matchesAll checks c against every filter
+func matchesAll(c CaughtPokemon, filters []Filter) bool {
+	for _, f := range filters {
+		if !f(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortLess returns the ordering for a sort key. Listings start out in ID
+// order, so a stable sort leaves ties ordered by ID.
+func sortLess(key SortKey) func(a, b CaughtPokemon) bool {
+	switch key {
+	case SortByName:
+		return func(a, b CaughtPokemon) bool { return a.Name < b.Name }
+	case SortByCaught:
+		return func(a, b CaughtPokemon) bool { return a.CaughtAt.Before(b.CaughtAt) }
+	case SortByWeight:
+		return func(a, b CaughtPokemon) bool { return a.Weight > b.Weight }
+	case SortByHeight:
+		return func(a, b CaughtPokemon) bool { return a.Height > b.Height }
+	case SortByBST:
+		return func(a, b CaughtPokemon) bool { return a.BaseStatTotal() > b.BaseStatTotal() }
+	case SortByType:
+		return func(a, b CaughtPokemon) bool {
+			if ta, tb := a.formatTypes(), b.formatTypes(); ta != tb {
+				return ta < tb
+			}
+			return a.Name < b.Name
+		}
+	}
+	return func(a, b CaughtPokemon) bool { return a.ID < b.ID }
+}
+
+// NextID returns the ID the next caught Pokemon will get
+func (pd *Pokedex) NextID() int {
+	return pd.nextID
 }
 
 // Count returns the number of caught Pokemon
@@ -49,11 +243,44 @@ func (pd *Pokedex) Count() int {
 	return len(pd.caught)
 }
 
+// Update replaces a caught Pokemon's details, e.g. after training or
+// evolving it, keeping its ID, nickname and where it was caught
+func (pd *Pokedex) Update(id int, p Pokemon) bool {
+	c, exists := pd.caught[id]
+	if !exists {
+		return false
+	}
+	c.Pokemon = p
+	pd.caught[id] = c
+	return true
+}
+
+// Nickname sets or, given an empty name, clears a caught Pokemon's nickname.
+// Nicknames must be unique, ignoring case, and must not look like IDs.
+func (pd *Pokedex) Nickname(id int, nickname string) error {
+	c, exists := pd.caught[id]
+	if !exists {
+		return fmt.Errorf("no Pokemon with ID %d: %w", id, ErrNotCaught)
+	}
+	if _, err := strconv.Atoi(strings.TrimPrefix(nickname, "#")); err == nil {
+		return fmt.Errorf("nickname %s looks like an ID", nickname)
+	}
+	for _, other := range pd.caught {
+		if nickname != "" && other.ID != id && strings.EqualFold(other.Nickname, nickname) {
+			return fmt.Errorf("%s is already the nickname of #%d", nickname, other.ID)
+		}
+	}
+
+	c.Nickname = nickname
+	pd.caught[id] = c
+	return nil
+}
+
 // Release removes a Pokemon from the Pokedex
-func (pd *Pokedex) Release(name string) bool {
-	if !pd.HasCaught(name) {
+func (pd *Pokedex) Release(id int) bool {
+	if _, exists := pd.caught[id]; !exists {
 		return false
 	}
-	delete(pd.caught, name)
+	delete(pd.caught, id)
 	return true
 }
@@ -0,0 +1,30 @@
+package pokemon
+
+import "testing"
+
+func TestFindNicknameIgnoresCase(t *testing.T) {
+	pd := NewPokedex()
+	c := pd.Catch(CaughtPokemon{Pokemon: Pokemon{Name: "pikachu"}, CaughtAt: testStart})
+	if err := pd.Nickname(c.ID, "Sparky"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ref := range []string{"Sparky", "sparky", "SPARKY", "Pikachu"} {
+		got, err := pd.Find(ref)
+		if err != nil {
+			t.Errorf("Find(%q) error = %v", ref, err)
+			continue
+		}
+		if got.ID != c.ID {
+			t.Errorf("Find(%q) = #%d, want #%d", ref, got.ID, c.ID)
+		}
+	}
+	if got, _ := pd.Get(c.ID); got.Nickname != "Sparky" {
+		t.Errorf("Nickname = %q, want the case it was given in", got.Nickname)
+	}
+
+	other := pd.Catch(CaughtPokemon{Pokemon: Pokemon{Name: "raichu"}, CaughtAt: testStart})
+	if err := pd.Nickname(other.ID, "sparky"); err == nil {
+		t.Error("Nickname() accepted a nickname differing only in case")
+	}
+}
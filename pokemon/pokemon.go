@@ -3,8 +3,18 @@ package pokemon
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
+// BaseFriendship is the friendship a freshly caught Pokemon starts with
+const BaseFriendship = 70
+
+// HistoryEntry is a notable event in a caught Pokemon's life
+type HistoryEntry struct {
+	Time  time.Time
+	Event string
+}
+
 // Pokemon represents our core domain entity
 type Pokemon struct {
 	Name           string
@@ -13,12 +23,88 @@ type Pokemon struct {
 	BaseExperience int
 	Types          []string
 	Stats          map[string]int // hp, attack, defense, etc.
+	Abilities      []PokemonAbility
+	SpeciesName    string
+	Species        *Species // nil until species data has been fetched
+	Learnset       Learnset
+
+	// Set once the Pokemon has been caught
+	Level      int
+	Friendship int
+	HeldItem   string
+	History    []HistoryEntry
 }
 
 // Business rules for Pokemon
 func (p Pokemon) String() string {
-	return fmt.Sprintf("Name: %s\nHeight: %d\nWeight: %d\nStats:\n%s\nType: %s",
-		p.Name, p.Height, p.Weight, p.formatStats(), p.formatTypes())
+	return fmt.Sprintf("Name: %s\nHeight: %d\nWeight: %d\nStats:\n%s\nType: %s%s%s%s%s",
+		p.Name, p.Height, p.Weight, p.formatStats(), p.formatTypes(), p.formatAbilities(),
+		p.formatLevel(), p.formatSpecies(), p.formatHistory())
+}
+
+func (p Pokemon) formatAbilities() string {
+	if len(p.Abilities) == 0 {
+		return ""
+	}
+
+	var result strings.Builder
+	result.WriteString("\nAbilities:")
+	for _, a := range p.Abilities {
+		result.WriteString(fmt.Sprintf("\n  -%s", a.Name))
+		if a.IsHidden {
+			result.WriteString(" (hidden)")
+		}
+		if a.Effect != "" {
+			result.WriteString(": " + a.Effect)
+		}
+	}
+	return result.String()
+}
+
+func (p Pokemon) formatLevel() string {
+	if p.Level == 0 {
+		return ""
+	}
+	level := fmt.Sprintf("\nLevel: %d\nFriendship: %d", p.Level, p.Friendship)
+	if p.HeldItem != "" {
+		level += "\nHolding: " + p.HeldItem
+	}
+	return level
+}
+
+func (p Pokemon) formatHistory() string {
+	if len(p.History) == 0 {
+		return ""
+	}
+
+	var result strings.Builder
+	result.WriteString("\nHistory:")
+	for _, h := range p.History {
+		result.WriteString(fmt.Sprintf("\n  -%s: %s", h.Time.Format(time.DateTime), h.Event))
+	}
+	return result.String()
+}
+
+func (p Pokemon) formatSpecies() string {
+	if p.Species == nil {
+		return ""
+	}
+
+	s := p.Species
+	var result strings.Builder
+	result.WriteString(fmt.Sprintf("\nGeneration: %s", s.Generation))
+	result.WriteString(fmt.Sprintf("\nGrowth rate: %s", s.GrowthRate))
+	result.WriteString(fmt.Sprintf("\nCapture rate: %d", s.CaptureRate))
+	if s.IsLegendary {
+		result.WriteString("\nLegendary: yes")
+	}
+	if s.IsMythical {
+		result.WriteString("\nMythical: yes")
+	}
+	if s.FlavorText != "" {
+		result.WriteString(fmt.Sprintf("\n\n%s", s.FlavorText))
+	}
+	return result.String()
 }
 
 func (p Pokemon) formatStats() string {
@@ -33,13 +119,26 @@ func (p Pokemon) formatTypes() string {
 	return strings.Join(p.Types, ", ")
 }
 
+// BaseStatTotal sums the Pokemon's base stats
+func (p Pokemon) BaseStatTotal() int {
+	total := 0
+	for _, value := range p.Stats {
+		total += value
+	}
+	return total
+}
+
 // IsCatchable determines if a Pokemon can be caught based on business rules
 func (p Pokemon) IsCatchable() bool {
 	return p.BaseExperience > 0 // Simple business rule
 }
 
-// CatchDifficulty returns how hard this Pokemon is to catch (0.0 to 1.0)
+// CatchDifficulty returns how hard this Pokemon is to catch (0.0 to 1.0),
+// using the species capture rate when known
 func (p Pokemon) CatchDifficulty() float64 {
+	if p.Species != nil {
+		return p.Species.CatchDifficulty()
+	}
 	if p.BaseExperience <= 0 {
 		return 0.0
 	}
@@ -50,7 +149,16 @@ func (p Pokemon) CatchDifficulty() float64 {
 	return difficulty
 }
 
-// IsLegendary checks if this Pokemon is likely legendary based on base experience
+// IsLegendary checks if this Pokemon is legendary, falling back to a guess
+// from base experience when species data is missing
 func (p Pokemon) IsLegendary() bool {
+	if p.Species != nil {
+		return p.Species.IsLegendary
+	}
 	return p.BaseExperience > 200
 }
+
+// IsMythical checks if this Pokemon is mythical; unknown without species data
+func (p Pokemon) IsMythical() bool {
+	return p.Species != nil && p.Species.IsMythical
+}
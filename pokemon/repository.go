@@ -0,0 +1,134 @@
+package pokemon
+
+import (
+	"fmt"
+	"time"
+)
+
+// CatchAttempt records one ball thrown at a Pokemon
+type CatchAttempt struct {
+	Time     time.Time
+	Pokemon  string
+	Location string // explored area the attempt was made in, if any
+	Rate     float64
+	Caught   bool
+	CaughtID int // ID the Pokemon was given if it was caught
+}
+
+// Exploration records a visit to a location area and what was found there
+type Exploration struct {
+	Time    time.Time
+	Area    string
+	Pokemon []string
+}
+
+// PokedexRepository stores a trainer's Pokedex along with a log of their
+// catch attempts and explorations
+type PokedexRepository interface {
+	// Load returns the stored Pokedex, or an empty one if nothing is stored
+	Load() (*Pokedex, error)
+	// Save replaces the stored Pokedex with pd
+	Save(pd *Pokedex) error
+
+	RecordCatchAttempt(a CatchAttempt) error
+	RecordExploration(e Exploration) error
+	// CatchAttempts returns attempts made at or after since, oldest first
+	CatchAttempts(since time.Time) ([]CatchAttempt, error)
+	// Explorations returns explorations made at or after since, oldest first
+	Explorations(since time.Time) ([]Exploration, error)
+
+	Close() error
+}
+
+// RestorePokedex rebuilds a Pokedex from stored Pokemon, keeping their IDs.
+// nextID is raised if needed so new catches never reuse an ID. Caught
+// Pokemon missing from seen, as in saves from before seen was tracked, are
+// marked seen when they were caught.
+func RestorePokedex(caught []CaughtPokemon, seen []Sighting, nextID int) (*Pokedex, error) {
+	pd := NewPokedex()
+	for _, s := range seen {
+		pd.See(s.Name, s.FirstSeen)
+	}
+	for _, c := range caught {
+		if _, exists := pd.caught[c.ID]; exists || c.ID <= 0 {
+			return nil, fmt.Errorf("invalid Pokemon ID %d", c.ID)
+		}
+		pd.caught[c.ID] = c
+		pd.See(c.Name, c.CaughtAt)
+		pd.nextID = max(pd.nextID, c.ID+1)
+	}
+	pd.nextID = max(pd.nextID, nextID)
+
+	return pd, nil
+}
+
+// MemoryRepository is a PokedexRepository that keeps everything in memory,
+// for tests and for running without anywhere to save to
+type MemoryRepository struct {
+	caught       []CaughtPokemon
+	seen         []Sighting
+	nextID       int
+	attempts     []CatchAttempt
+	explorations []Exploration
+}
+
+// NewMemoryRepository creates an empty in-memory repository
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		nextID: 1,
+	}
+}
+
+// Load returns a copy of the stored Pokedex
+func (r *MemoryRepository) Load() (*Pokedex, error) {
+	return RestorePokedex(r.caught, r.seen, r.nextID)
+}
+
+// Save stores a snapshot of pd
+func (r *MemoryRepository) Save(pd *Pokedex) error {
+	r.caught = pd.All()
+	r.seen = pd.Sightings()
+	r.nextID = pd.NextID()
+	return nil
+}
+
+// RecordCatchAttempt appends to the catch log
+func (r *MemoryRepository) RecordCatchAttempt(a CatchAttempt) error {
+	r.attempts = append(r.attempts, a)
+	return nil
+}
+
+// RecordExploration appends to the exploration log
+func (r *MemoryRepository) RecordExploration(e Exploration) error {
+	r.explorations = append(r.explorations, e)
+	return nil
+}
+
+// CatchAttempts returns attempts made at or after since
+func (r *MemoryRepository) CatchAttempts(since time.Time) ([]CatchAttempt, error) {
+	var result []CatchAttempt
+	for _, a := range r.attempts {
+		if !a.Time.Before(since) {
+			result = append(result, a)
+		}
+	}
+	return result, nil
+}
+
+// Explorations returns explorations made at or after since
+func (r *MemoryRepository) Explorations(since time.Time) ([]Exploration, error) {
+	var result []Exploration
+	for _, e := range r.explorations {
+		if !e.Time.Before(since) {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+// Close does nothing; the data lives as long as the repository
+func (r *MemoryRepository) Close() error {
+	return nil
+}
+
+var _ PokedexRepository = (*MemoryRepository)(nil)
@@ -0,0 +1,14 @@
+package pokemon_test
+
+import (
+	"testing"
+
+	"github.com/mcoluomo/pokedexcli/pokemon"
+	"github.com/mcoluomo/pokedexcli/repotest"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) pokemon.PokedexRepository {
+		return pokemon.NewMemoryRepository()
+	})
+}
@@ -0,0 +1,31 @@
+package pokemon
+
+import (
+	"testing"
+	"time"
+)
+
+var testStart = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestRestorePokedexRejectsBadIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []int
+	}{
+		{"zero", []int{0}},
+		{"negative", []int{-1}},
+		{"duplicate", []int{3, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var caught []CaughtPokemon
+			for _, id := range tt.ids {
+				caught = append(caught, CaughtPokemon{ID: id, Pokemon: Pokemon{Name: "pikachu"}})
+			}
+			if _, err := RestorePokedex(caught, nil, 1); err == nil {
+				t.Error("RestorePokedex() error = nil, want an error")
+			}
+		})
+	}
+}
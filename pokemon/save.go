@@ -0,0 +1,224 @@
+package pokemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mcoluomo/pokedexcli/internal/atomicfile"
+)
+
+// SaveVersion is the save file format written by this build. Files with a
+// newer version are refused rather than silently losing data.
+const SaveVersion = 2
+
+// saveFile is the on-disk representation of a Pokedex
+type saveFile struct {
+	Version int             `json:"version"`
+	SavedAt time.Time       `json:"saved_at"`
+	NextID  int             `json:"next_id"`
+	Pokemon []savedPokemon  `json:"pokemon"`
+	Seen    []savedSighting `json:"seen,omitempty"` // added in version 2
+}
+
+// savedPokemon is the on-disk representation of a CaughtPokemon. The
+// learnset is left out since it is large and always refetched.
+type savedPokemon struct {
+	ID             int            `json:"id"`
+	Nickname       string         `json:"nickname,omitempty"`
+	CaughtAt       time.Time      `json:"caught_at"`
+	Location       string         `json:"location,omitempty"`
+	Name           string         `json:"name"`
+	Height         int            `json:"height"`
+	Weight         int            `json:"weight"`
+	BaseExperience int            `json:"base_experience"`
+	Types          []string       `json:"types"`
+	Stats          map[string]int `json:"stats"`
+	Abilities      []savedAbility `json:"abilities,omitempty"`
+	SpeciesName    string         `json:"species_name,omitempty"`
+	Species        *savedSpecies  `json:"species,omitempty"`
+	Level          int            `json:"level"`
+	Friendship     int            `json:"friendship"`
+	HeldItem       string         `json:"held_item,omitempty"`
+	History        []savedEvent   `json:"history,omitempty"`
+}
+
+type savedAbility struct {
+	Name     string `json:"name"`
+	IsHidden bool   `json:"is_hidden,omitempty"`
+	Slot     int    `json:"slot"`
+}
+
+type savedSpecies struct {
+	Name        string `json:"name"`
+	CaptureRate int    `json:"capture_rate"`
+	IsLegendary bool   `json:"is_legendary,omitempty"`
+	IsMythical  bool   `json:"is_mythical,omitempty"`
+	GrowthRate  string `json:"growth_rate,omitempty"`
+	Generation  string `json:"generation,omitempty"`
+	FlavorText  string `json:"flavor_text,omitempty"`
+}
+
+type savedSighting struct {
+	Name      string    `json:"name"`
+	FirstSeen time.Time `json:"first_seen"`
+}
+
+type savedEvent struct {
+	Time  time.Time `json:"time"`
+	Event string    `json:"event"`
+}
+
+// Save atomically writes the Pokedex to path
+func (pd *Pokedex) Save(path string, at time.Time) error {
+	file := saveFile{
+		Version: SaveVersion,
+		SavedAt: at,
+		NextID:  pd.NextID(),
+		Pokemon: make([]savedPokemon, 0, len(pd.caught)),
+	}
+	for _, c := range pd.All() {
+		file.Pokemon = append(file.Pokemon, toSaved(c))
+	}
+	for _, s := range pd.Sightings() {
+		file.Seen = append(file.Seen, savedSighting{Name: s.Name, FirstSeen: s.FirstSeen})
+	}
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode save file: %w", err)
+	}
+
+	if err := atomicfile.Write(path, data); err != nil {
+		return fmt.Errorf("failed to write save file: %w", err)
+	}
+	return nil
+}
+
+// LoadPokedex reads a Pokedex saved with Save. A missing file is reported
+// with an error wrapping os.ErrNotExist.
+func LoadPokedex(path string) (*Pokedex, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file saveFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("save file %s is corrupt: %w", path, err)
+	}
+	if file.Version < 1 || file.Version > SaveVersion {
+		return nil, fmt.Errorf("save file %s has unsupported version %d (this build reads up to %d)",
+			path, file.Version, SaveVersion)
+	}
+
+	caught := make([]CaughtPokemon, len(file.Pokemon))
+	for i, saved := range file.Pokemon {
+		caught[i] = fromSaved(saved)
+	}
+
+	seen := make([]Sighting, len(file.Seen))
+	for i, s := range file.Seen {
+		seen[i] = Sighting{Name: s.Name, FirstSeen: s.FirstSeen}
+	}
+
+	pd, err := RestorePokedex(caught, seen, file.NextID)
+	if err != nil {
+		return nil, fmt.Errorf("save file %s is corrupt: %w", path, err)
+	}
+	return pd, nil
+}
+
+// toSaved converts a caught Pokemon to its on-disk form
+func toSaved(c CaughtPokemon) savedPokemon {
+	saved := savedPokemon{
+		ID:             c.ID,
+		Nickname:       c.Nickname,
+		CaughtAt:       c.CaughtAt,
+		Location:       c.Location,
+		Name:           c.Name,
+		Height:         c.Height,
+		Weight:         c.Weight,
+		BaseExperience: c.BaseExperience,
+		Types:          c.Types,
+		Stats:          c.Stats,
+		SpeciesName:    c.SpeciesName,
+		Level:          c.Level,
+		Friendship:     c.Friendship,
+		HeldItem:       c.HeldItem,
+	}
+
+	for _, a := range c.Abilities {
+		saved.Abilities = append(saved.Abilities, savedAbility{
+			Name:     a.Name,
+			IsHidden: a.IsHidden,
+			Slot:     a.Slot,
+		})
+	}
+	if s := c.Species; s != nil {
+		saved.Species = &savedSpecies{
+			Name:        s.Name,
+			CaptureRate: s.CaptureRate,
+			IsLegendary: s.IsLegendary,
+			IsMythical:  s.IsMythical,
+			GrowthRate:  s.GrowthRate,
+			Generation:  s.Generation,
+			FlavorText:  s.FlavorText,
+		}
+	}
+	for _, h := range c.History {
+		saved.History = append(saved.History, savedEvent{Time: h.Time, Event: h.Event})
+	}
+
+	return saved
+}
+
+// fromSaved converts an on-disk Pokemon back to the domain model
+func fromSaved(saved savedPokemon) CaughtPokemon {
+	c := CaughtPokemon{
+		ID:       saved.ID,
+		Nickname: saved.Nickname,
+		CaughtAt: saved.CaughtAt,
+		Location: saved.Location,
+		Pokemon: Pokemon{
+			Name:           saved.Name,
+			Height:         saved.Height,
+			Weight:         saved.Weight,
+			BaseExperience: saved.BaseExperience,
+			Types:          saved.Types,
+			Stats:          saved.Stats,
+			SpeciesName:    saved.SpeciesName,
+			Level:          saved.Level,
+			Friendship:     saved.Friendship,
+			HeldItem:       saved.HeldItem,
+		},
+	}
+	if c.Stats == nil {
+		c.Stats = make(map[string]int)
+	}
+
+	for _, a := range saved.Abilities {
+		c.Abilities = append(c.Abilities, PokemonAbility{
+			Name:     a.Name,
+			IsHidden: a.IsHidden,
+			Slot:     a.Slot,
+		})
+	}
+	if s := saved.Species; s != nil {
+		c.Species = &Species{
+			Name:        s.Name,
+			CaptureRate: s.CaptureRate,
+			IsLegendary: s.IsLegendary,
+			IsMythical:  s.IsMythical,
+			GrowthRate:  s.GrowthRate,
+			Generation:  s.Generation,
+			FlavorText:  s.FlavorText,
+		}
+	}
+	for _, h := range saved.History {
+		c.History = append(c.History, HistoryEntry{Time: h.Time, Event: h.Event})
+	}
+
+	return c
+}
@@ -0,0 +1,23 @@
+package pokemon
+
+// Species holds the data shared by every Pokemon of a species
+type Species struct {
+	Name        string
+	CaptureRate int // 0-255, higher is easier to catch
+	IsLegendary bool
+	IsMythical  bool
+	GrowthRate  string
+	Generation  string
+	FlavorText  string
+}
+
+// CatchDifficulty converts the capture rate into a difficulty (0.0 to 1.0)
+func (s Species) CatchDifficulty() float64 {
+	if s.CaptureRate <= 0 {
+		return 1.0
+	}
+	if s.CaptureRate >= 255 {
+		return 0.0
+	}
+	return 1.0 - float64(s.CaptureRate)/255.0
+}
@@ -0,0 +1,64 @@
+package pokemon
+
+import (
+	"fmt"
+	"time"
+)
+
+// Level and friendship limits, as in the games
+const (
+	MaxLevel      = 100
+	MaxFriendship = 255
+)
+
+// LevelUp raises the Pokemon by up to levels levels, stopping at MaxLevel.
+// Each level gained raises friendship the way levelling up does in the
+// games: quickly at first and more slowly as the Pokemon grows attached.
+func (p Pokemon) LevelUp(levels int, at time.Time) (Pokemon, error) {
+	if levels <= 0 {
+		return p, fmt.Errorf("levels must be positive")
+	}
+	if p.Level >= MaxLevel {
+		return p, fmt.Errorf("%s is already level %d", p.Name, MaxLevel)
+	}
+
+	from := p.Level
+	for i := 0; i < levels && p.Level < MaxLevel; i++ {
+		p.Level++
+		p.Friendship = min(p.Friendship+friendshipGain(p.Friendship), MaxFriendship)
+	}
+
+	p.History = append(append([]HistoryEntry(nil), p.History...), HistoryEntry{
+		Time:  at,
+		Event: fmt.Sprintf("grew from level %d to %d", from, p.Level),
+	})
+	return p, nil
+}
+
+// friendshipGain is how much friendship a level-up adds
+func friendshipGain(friendship int) int {
+	switch {
+	case friendship < 100:
+		return 5
+	case friendship < 200:
+		return 3
+	default:
+		return 2
+	}
+}
+
+// Hold gives the Pokemon an item to hold, or takes its item away if item
+// is empty
+func (p Pokemon) Hold(item string, at time.Time) Pokemon {
+	event := "was given the " + item + " to hold"
+	if item == "" {
+		event = "stopped holding its " + p.HeldItem
+	}
+
+	p.HeldItem = item
+	p.History = append(append([]HistoryEntry(nil), p.History...), HistoryEntry{
+		Time:  at,
+		Event: event,
+	})
+	return p
+}
@@ -0,0 +1,83 @@
+package pokemon
+
+import "testing"
+
+func TestLevelUp(t *testing.T) {
+	tests := []struct {
+		name           string
+		level          int
+		friendship     int
+		levels         int
+		wantLevel      int
+		wantFriendship int
+	}{
+		{"one level", 10, BaseFriendship, 1, 11, BaseFriendship + 5},
+		{"slower past 100 friendship", 10, 98, 2, 12, 98 + 5 + 3},
+		{"slowest past 200 friendship", 10, 199, 2, 12, 199 + 3 + 2},
+		{"friendship is capped", 10, 254, 3, 13, MaxFriendship},
+		{"level is capped", 98, BaseFriendship, 5, MaxLevel, BaseFriendship + 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Pokemon{Name: "zubat", Level: tt.level, Friendship: tt.friendship}
+
+			got, err := p.LevelUp(tt.levels, testStart)
+			if err != nil {
+				t.Fatalf("LevelUp() error = %v", err)
+			}
+			if got.Level != tt.wantLevel {
+				t.Errorf("Level = %d, want %d", got.Level, tt.wantLevel)
+			}
+			if got.Friendship != tt.wantFriendship {
+				t.Errorf("Friendship = %d, want %d", got.Friendship, tt.wantFriendship)
+			}
+			if len(got.History) != 1 {
+				t.Errorf("History has %d entries, want 1", len(got.History))
+			}
+		})
+	}
+}
+
+func TestLevelUpRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		level  int
+		levels int
+	}{
+		{"no levels", 10, 0},
+		{"negative levels", 10, -1},
+		{"already max level", MaxLevel, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Pokemon{Name: "zubat", Level: tt.level}
+			if _, err := p.LevelUp(tt.levels, testStart); err == nil {
+				t.Error("LevelUp() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestTrainingMeetsEvolutionRequirements(t *testing.T) {
+	crobat := EvolutionRequirement{Trigger: TriggerLevelUp, MinHappiness: 160}
+	p := Pokemon{Name: "golbat", Level: 22, Friendship: BaseFriendship}
+
+	if err := crobat.Check(p, EvolutionContext{Time: testStart}); err == nil {
+		t.Fatal("Check() passed before training")
+	}
+
+	trained, err := p.LevelUp(30, testStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := crobat.Check(trained, EvolutionContext{Time: testStart}); err != nil {
+		t.Errorf("Check() after training = %v, want nil", err)
+	}
+
+	held := EvolutionRequirement{Trigger: TriggerLevelUp, HeldItem: "oval-stone"}
+	if err := held.Check(trained.Hold("oval-stone", testStart), EvolutionContext{Time: testStart}); err != nil {
+		t.Errorf("Check() holding the item = %v, want nil", err)
+	}
+}
@@ -0,0 +1,169 @@
+package pokemon
+
+import "sort"
+
+// TypeRelations are the damage relations of a single type, as PokeAPI
+// reports them from both the attacking and defending side
+type TypeRelations struct {
+	Name       string
+	DoubleTo   []string
+	HalfTo     []string
+	NoDamageTo []string
+
+	DoubleFrom   []string
+	HalfFrom     []string
+	NoDamageFrom []string
+}
+
+// TypeChart holds damage multipliers between types
+type TypeChart struct {
+	types       map[string]bool
+	multipliers map[string]map[string]float64 // attacking -> defending -> multiplier
+}
+
+// Matchup summarises how a (possibly dual) type fares defensively
+type Matchup struct {
+	Defending   []string
+	Multipliers map[string]float64 // attacking type -> combined multiplier
+}
+
+// NewTypeChart creates an empty chart in which everything is neutral
+func NewTypeChart() *TypeChart {
+	return &TypeChart{
+		types:       make(map[string]bool),
+		multipliers: make(map[string]map[string]float64),
+	}
+}
+
+// DefaultTypeChart returns the built-in chart used when PokeAPI type data
+// is unavailable
+func DefaultTypeChart() *TypeChart {
+	tc := NewTypeChart()
+	for _, rel := range fallbackTypeRelations {
+		tc.Apply(rel)
+	}
+	return tc
+}
+
+// Apply updates the chart with a type's damage relations. Each side
+// (attacking or defending) that rel has data for replaces what was known.
+func (tc *TypeChart) Apply(rel TypeRelations) {
+	tc.types[rel.Name] = true
+
+	// Reset the sides we have data for to neutral
+	if len(rel.DoubleTo)+len(rel.HalfTo)+len(rel.NoDamageTo) > 0 {
+		delete(tc.multipliers, rel.Name)
+	}
+	if len(rel.DoubleFrom)+len(rel.HalfFrom)+len(rel.NoDamageFrom) > 0 {
+		for _, row := range tc.multipliers {
+			delete(row, rel.Name)
+		}
+	}
+
+	for _, t := range rel.DoubleTo {
+		tc.set(rel.Name, t, 2.0)
+	}
+	for _, t := range rel.HalfTo {
+		tc.set(rel.Name, t, 0.5)
+	}
+	for _, t := range rel.NoDamageTo {
+		tc.set(rel.Name, t, 0.0)
+	}
+	for _, t := range rel.DoubleFrom {
+		tc.set(t, rel.Name, 2.0)
+	}
+	for _, t := range rel.HalfFrom {
+		tc.set(t, rel.Name, 0.5)
+	}
+	for _, t := range rel.NoDamageFrom {
+		tc.set(t, rel.Name, 0.0)
+	}
+}
+
+// set records a multiplier between two types
+func (tc *TypeChart) set(attacking, defending string, m float64) {
+	tc.types[attacking] = true
+	tc.types[defending] = true
+
+	row, ok := tc.multipliers[attacking]
+	if !ok {
+		row = make(map[string]float64)
+		tc.multipliers[attacking] = row
+	}
+	row[defending] = m
+}
+
+// IsType reports whether name is a type known to the chart
+func (tc *TypeChart) IsType(name string) bool {
+	return tc.types[name]
+}
+
+// Types returns every known type in alphabetical order
+func (tc *TypeChart) Types() []string {
+	types := make([]string, 0, len(tc.types))
+	for t := range tc.types {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Multiplier returns the damage multiplier of an attacking type against a
+// defender with one or more types
+func (tc *TypeChart) Multiplier(attacking string, defending []string) float64 {
+	result := 1.0
+	for _, d := range defending {
+		if m, ok := tc.multipliers[attacking][d]; ok {
+			result *= m
+		}
+	}
+	return result
+}
+
+// Matchup computes how every attacking type fares against defending
+func (tc *TypeChart) Matchup(defending []string) Matchup {
+	m := Matchup{
+		Defending:   defending,
+		Multipliers: make(map[string]float64),
+	}
+	for _, attacking := range tc.Types() {
+		m.Multipliers[attacking] = tc.Multiplier(attacking, defending)
+	}
+	return m
+}
+
+// With returns the attacking types whose combined multiplier is exactly m,
+// in alphabetical order
+func (m Matchup) With(multiplier float64) []string {
+	var types []string
+	for t, value := range m.Multipliers {
+		if value == multiplier {
+			types = append(types, t)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// fallbackTypeRelations is the current (generation VI onwards) type chart
+// from the attacking side
+var fallbackTypeRelations = []TypeRelations{
+	{Name: "normal", HalfTo: []string{"rock", "steel"}, NoDamageTo: []string{"ghost"}},
+	{Name: "fire", DoubleTo: []string{"grass", "ice", "bug", "steel"}, HalfTo: []string{"fire", "water", "rock", "dragon"}},
+	{Name: "water", DoubleTo: []string{"fire", "ground", "rock"}, HalfTo: []string{"water", "grass", "dragon"}},
+	{Name: "electric", DoubleTo: []string{"water", "flying"}, HalfTo: []string{"electric", "grass", "dragon"}, NoDamageTo: []string{"ground"}},
+	{Name: "grass", DoubleTo: []string{"water", "ground", "rock"}, HalfTo: []string{"fire", "grass", "poison", "flying", "bug", "dragon", "steel"}},
+	{Name: "ice", DoubleTo: []string{"grass", "ground", "flying", "dragon"}, HalfTo: []string{"fire", "water", "ice", "steel"}},
+	{Name: "fighting", DoubleTo: []string{"normal", "ice", "rock", "dark", "steel"}, HalfTo: []string{"poison", "flying", "psychic", "bug", "fairy"}, NoDamageTo: []string{"ghost"}},
+	{Name: "poison", DoubleTo: []string{"grass", "fairy"}, HalfTo: []string{"poison", "ground", "rock", "ghost"}, NoDamageTo: []string{"steel"}},
+	{Name: "ground", DoubleTo: []string{"fire", "electric", "poison", "rock", "steel"}, HalfTo: []string{"grass", "bug"}, NoDamageTo: []string{"flying"}},
+	{Name: "flying", DoubleTo: []string{"grass", "fighting", "bug"}, HalfTo: []string{"electric", "rock", "steel"}},
+	{Name: "psychic", DoubleTo: []string{"fighting", "poison"}, HalfTo: []string{"psychic", "steel"}, NoDamageTo: []string{"dark"}},
+	{Name: "bug", DoubleTo: []string{"grass", "psychic", "dark"}, HalfTo: []string{"fire", "fighting", "poison", "flying", "ghost", "steel", "fairy"}},
+	{Name: "rock", DoubleTo: []string{"fire", "ice", "flying", "bug"}, HalfTo: []string{"fighting", "ground", "steel"}},
+	{Name: "ghost", DoubleTo: []string{"psychic", "ghost"}, HalfTo: []string{"dark"}, NoDamageTo: []string{"normal"}},
+	{Name: "dragon", DoubleTo: []string{"dragon"}, HalfTo: []string{"steel"}, NoDamageTo: []string{"fairy"}},
+	{Name: "dark", DoubleTo: []string{"psychic", "ghost"}, HalfTo: []string{"fighting", "dark", "fairy"}},
+	{Name: "steel", DoubleTo: []string{"ice", "rock", "fairy"}, HalfTo: []string{"fire", "water", "electric", "steel"}},
+	{Name: "fairy", DoubleTo: []string{"fighting", "dragon", "dark"}, HalfTo: []string{"fire", "poison", "steel"}},
+}
@@ -0,0 +1,225 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/mcoluomo/pokedexcli/internal/atomicfile"
+)
+
+// DefaultName is the profile used when none is chosen
+const DefaultName = "default"
+
+const (
+	profileFileName  = "profile.json"
+	databaseFileName = "pokedex.db"
+	saveFileName     = "pokedex.json"
+)
+
+var (
+	// ErrNotFound is returned for a profile that doesn't exist
+	ErrNotFound = errors.New("profile not found")
+	// ErrExists is returned when creating a profile that already exists
+	ErrExists = errors.New("profile already exists")
+)
+
+// validName keeps profile names usable as directory names everywhere
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// Settings are the per-trainer preferences stored with a profile
+type Settings struct {
+	Autosave bool `json:"autosave"`
+}
+
+// DefaultSettings returns the settings new profiles start with
+func DefaultSettings() Settings {
+	return Settings{
+		Autosave: true,
+	}
+}
+
+// Profile is one trainer's save slot: their Pokedex and settings
+type Profile struct {
+	Name      string
+	CreatedAt time.Time
+	Settings  Settings
+	dir       string
+}
+
+// DatabasePath returns the SQLite database the profile's Pokedex and
+// activity log are stored in
+func (p Profile) DatabasePath() string {
+	return filepath.Join(p.dir, databaseFileName)
+}
+
+// LegacySavePath returns the JSON file the Pokedex was saved in before
+// profiles moved to SQLite
+func (p Profile) LegacySavePath() string {
+	return filepath.Join(p.dir, saveFileName)
+}
+
+// profileFile is the on-disk representation of a Profile
+type profileFile struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	Settings  Settings  `json:"settings"`
+}
+
+// Manager stores profiles as directories under a root directory
+type Manager struct {
+	root string
+}
+
+// DefaultRoot returns the per-user directory profiles are kept in
+func DefaultRoot() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "pokedexcli", "profiles"), nil
+}
+
+// PreProfileSavePath returns the per-user save file the Pokedex was kept in
+// before there were profiles
+func PreProfileSavePath() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "pokedexcli", "save.json"), nil
+}
+
+// NewManager creates a manager for the profiles under root
+func NewManager(root string) *Manager {
+	return &Manager{root: root}
+}
+
+// ValidateName reports why name can't be used for a profile, if it can't
+func ValidateName(name string) error {
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use up to 32 lowercase letters, digits, - or _", name)
+	}
+	return nil
+}
+
+// Create makes a new profile with default settings
+func (m *Manager) Create(name string, at time.Time) (Profile, error) {
+	if err := ValidateName(name); err != nil {
+		return Profile{}, err
+	}
+
+	dir := filepath.Join(m.root, name)
+	if _, err := os.Stat(filepath.Join(dir, profileFileName)); err == nil {
+		return Profile{}, fmt.Errorf("%s: %w", name, ErrExists)
+	}
+
+	p := Profile{
+		Name:      name,
+		CreatedAt: at,
+		Settings:  DefaultSettings(),
+		dir:       dir,
+	}
+	if err := m.SaveSettings(p); err != nil {
+		return Profile{}, err
+	}
+	return p, nil
+}
+
+// Get loads an existing profile
+func (m *Manager) Get(name string) (Profile, error) {
+	if err := ValidateName(name); err != nil {
+		return Profile{}, err
+	}
+
+	dir := filepath.Join(m.root, name)
+	data, err := os.ReadFile(filepath.Join(dir, profileFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return Profile{}, fmt.Errorf("%s: %w", name, ErrNotFound)
+	}
+	if err != nil {
+		return Profile{}, fmt.Errorf("failed to read profile %s: %w", name, err)
+	}
+
+	var file profileFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Profile{}, fmt.Errorf("profile %s is corrupt: %w", name, err)
+	}
+
+	return Profile{
+		Name:      name,
+		CreatedAt: file.CreatedAt,
+		Settings:  file.Settings,
+		dir:       dir,
+	}, nil
+}
+
+// Open loads a profile, creating it first if it doesn't exist yet
+func (m *Manager) Open(name string, at time.Time) (Profile, error) {
+	p, err := m.Get(name)
+	if errors.Is(err, ErrNotFound) {
+		return m.Create(name, at)
+	}
+	return p, err
+}
+
+// List returns every readable profile sorted by name
+func (m *Manager) List() ([]Profile, error) {
+	dirs, err := os.ReadDir(m.root)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+
+	var profiles []Profile
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		p, err := m.Get(d.Name())
+		if err != nil {
+			continue
+		}
+		profiles = append(profiles, p)
+	}
+
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+	return profiles, nil
+}
+
+// SaveSettings writes a profile's settings
+func (m *Manager) SaveSettings(p Profile) error {
+	data, err := json.MarshalIndent(profileFile{
+		Name:      p.Name,
+		CreatedAt: p.CreatedAt,
+		Settings:  p.Settings,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode profile %s: %w", p.Name, err)
+	}
+
+	if err := atomicfile.Write(filepath.Join(m.root, p.Name, profileFileName), data); err != nil {
+		return fmt.Errorf("failed to write profile %s: %w", p.Name, err)
+	}
+	return nil
+}
+
+// Delete removes a profile and everything saved in it
+func (m *Manager) Delete(name string) error {
+	if _, err := m.Get(name); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(m.root, name)); err != nil {
+		return fmt.Errorf("failed to delete profile %s: %w", name, err)
+	}
+	return nil
+}
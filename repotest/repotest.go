@@ -0,0 +1,257 @@
+// Package repotest checks that a pokemon.PokedexRepository behaves the way
+// the rest of the program expects, so every implementation can be held to
+// the same contract.
+package repotest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mcoluomo/pokedexcli/pokemon"
+)
+
+// start is a fixed, millisecond-precise time every test works from, since
+// repositories only have to keep times to the millisecond
+var start = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// Run tests the repositories returned by open, which must return a new,
+// empty repository each time it is called
+func Run(t *testing.T, open func(t *testing.T) pokemon.PokedexRepository) {
+	t.Run("EmptyLoad", func(t *testing.T) { testEmptyLoad(t, open(t)) })
+	t.Run("RoundTrip", func(t *testing.T) { testRoundTrip(t, open(t)) })
+	t.Run("SaveReplaces", func(t *testing.T) { testSaveReplaces(t, open(t)) })
+	t.Run("LoadIsACopy", func(t *testing.T) { testLoadIsACopy(t, open(t)) })
+	t.Run("LogRoundTrip", func(t *testing.T) { testLogRoundTrip(t, open(t)) })
+	t.Run("LogSince", func(t *testing.T) { testLogSince(t, open(t)) })
+}
+
+func testEmptyLoad(t *testing.T, repo pokemon.PokedexRepository) {
+	pd, err := repo.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := pd.Count(); got != 0 {
+		t.Errorf("Count() = %d, want 0", got)
+	}
+	if got := pd.NextID(); got != 1 {
+		t.Errorf("NextID() = %d, want 1", got)
+	}
+}
+
+func testRoundTrip(t *testing.T, repo pokemon.PokedexRepository) {
+	pd := pokemon.NewPokedex()
+	pikachu := pd.Catch(pokemon.CaughtPokemon{
+		Pokemon: pokemon.Pokemon{
+			Name:           "pikachu",
+			Height:         4,
+			Weight:         60,
+			BaseExperience: 112,
+			Types:          []string{"electric"},
+			Stats:          map[string]int{"hp": 35, "attack": 55, "speed": 90},
+			Abilities: []pokemon.PokemonAbility{
+				{Name: "static", Slot: 1, Effect: "May paralyze on contact."},
+				{Name: "lightning-rod", IsHidden: true, Slot: 3},
+			},
+			SpeciesName: "pikachu",
+			Species: &pokemon.Species{
+				Name:        "pikachu",
+				CaptureRate: 190,
+				GrowthRate:  "medium",
+				Generation:  "generation-i",
+				FlavorText:  "It keeps its tail raised to monitor its surroundings.",
+			},
+			Level:      12,
+			Friendship: 90,
+			HeldItem:   "light-ball",
+			History:    []pokemon.HistoryEntry{{Time: start.Add(time.Minute), Event: "grew from level 5 to 12"}},
+		},
+		CaughtAt: start,
+		Location: "viridian-forest-area",
+	})
+	pidgey := pd.Catch(pokemon.CaughtPokemon{
+		Pokemon:  pokemon.Pokemon{Name: "pidgey", Types: []string{"normal", "flying"}, Stats: map[string]int{}},
+		CaughtAt: start.Add(time.Hour),
+	})
+	if err := pd.Nickname(pikachu.ID, "Sparky"); err != nil {
+		t.Fatal(err)
+	}
+	pd.See("mew", start.Add(2*time.Hour))
+	pd.Release(pidgey.ID)
+
+	if err := repo.Save(pd); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := repo.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want, got := normalize(pd.All()), normalize(loaded.All())
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load() caught\n%+v\nwant\n%+v", got, want)
+	}
+	// A released Pokemon's ID is never handed out again
+	if got := loaded.NextID(); got != pd.NextID() {
+		t.Errorf("NextID() = %d, want %d", got, pd.NextID())
+	}
+
+	wantSeen, gotSeen := pd.Sightings(), loaded.Sightings()
+	if len(gotSeen) != len(wantSeen) {
+		t.Fatalf("Sightings() = %v, want %v", gotSeen, wantSeen)
+	}
+	for i := range wantSeen {
+		if gotSeen[i].Name != wantSeen[i].Name || !gotSeen[i].FirstSeen.Equal(wantSeen[i].FirstSeen) {
+			t.Errorf("Sightings()[%d] = %v, want %v", i, gotSeen[i], wantSeen[i])
+		}
+	}
+}
+
+func testSaveReplaces(t *testing.T, repo pokemon.PokedexRepository) {
+	first := pokemon.NewPokedex()
+	first.Catch(pokemon.CaughtPokemon{Pokemon: pokemon.Pokemon{Name: "pikachu"}, CaughtAt: start})
+	first.Catch(pokemon.CaughtPokemon{Pokemon: pokemon.Pokemon{Name: "pidgey"}, CaughtAt: start})
+	if err := repo.Save(first); err != nil {
+		t.Fatal(err)
+	}
+
+	second := pokemon.NewPokedex()
+	second.Catch(pokemon.CaughtPokemon{Pokemon: pokemon.Pokemon{Name: "eevee"}, CaughtAt: start})
+	if err := repo.Save(second); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := repo.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Count(); got != 1 {
+		t.Errorf("Count() = %d, want 1", got)
+	}
+	if loaded.HasSeen("pikachu") {
+		t.Error("HasSeen(pikachu) = true, want sightings replaced too")
+	}
+}
+
+func testLoadIsACopy(t *testing.T, repo pokemon.PokedexRepository) {
+	pd := pokemon.NewPokedex()
+	pd.Catch(pokemon.CaughtPokemon{Pokemon: pokemon.Pokemon{Name: "pikachu"}, CaughtAt: start})
+	if err := repo.Save(pd); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := repo.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded.Catch(pokemon.CaughtPokemon{Pokemon: pokemon.Pokemon{Name: "pidgey"}, CaughtAt: start})
+
+	again, err := repo.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := again.Count(); got != 1 {
+		t.Errorf("Count() after changing an unsaved copy = %d, want 1", got)
+	}
+}
+
+func testLogRoundTrip(t *testing.T, repo pokemon.PokedexRepository) {
+	attempts := []pokemon.CatchAttempt{
+		{Time: start, Pokemon: "pidgey", Location: "route-1-area", Rate: 0.75, Caught: true, CaughtID: 4},
+		{Time: start.Add(time.Second), Pokemon: "mewtwo", Rate: 0.05},
+	}
+	explorations := []pokemon.Exploration{
+		{Time: start, Area: "route-1-area", Pokemon: []string{"pidgey", "rattata"}},
+		{Time: start.Add(time.Second), Area: "empty-area", Pokemon: []string{}},
+	}
+	for _, a := range attempts {
+		if err := repo.RecordCatchAttempt(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, e := range explorations {
+		if err := repo.RecordExploration(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gotAttempts, err := repo.CatchAttempts(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range gotAttempts {
+		gotAttempts[i].Time = gotAttempts[i].Time.UTC()
+	}
+	if !reflect.DeepEqual(gotAttempts, attempts) {
+		t.Errorf("CatchAttempts() = %+v, want %+v", gotAttempts, attempts)
+	}
+
+	gotExplorations, err := repo.Explorations(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range gotExplorations {
+		gotExplorations[i].Time = gotExplorations[i].Time.UTC()
+	}
+	if !reflect.DeepEqual(gotExplorations, explorations) {
+		t.Errorf("Explorations() = %+v, want %+v", gotExplorations, explorations)
+	}
+}
+
+func testLogSince(t *testing.T, repo pokemon.PokedexRepository) {
+	for i, name := range []string{"pidgey", "rattata", "pikachu"} {
+		at := start.Add(time.Duration(i) * time.Hour)
+		if err := repo.RecordCatchAttempt(pokemon.CatchAttempt{Time: at, Pokemon: name}); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.RecordExploration(pokemon.Exploration{Time: at, Area: name + "-area"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		since time.Time
+		want  int
+	}{
+		{"everything", start, 3},
+		{"exactly at an entry", start.Add(time.Hour), 2},
+		{"just after an entry", start.Add(time.Hour + time.Nanosecond), 1},
+		{"after everything", start.Add(3 * time.Hour), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts, err := repo.CatchAttempts(tt.since)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(attempts) != tt.want {
+				t.Errorf("CatchAttempts() returned %d, want %d", len(attempts), tt.want)
+			}
+
+			explorations, err := repo.Explorations(tt.since)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(explorations) != tt.want {
+				t.Errorf("Explorations() returned %d, want %d", len(explorations), tt.want)
+			}
+		})
+	}
+}
+
+// normalize puts every time in UTC, so Pokemon compare equal however a
+// repository restores its times
+func normalize(caught []pokemon.CaughtPokemon) []pokemon.CaughtPokemon {
+	for i, c := range caught {
+		c.CaughtAt = c.CaughtAt.UTC()
+		history := make([]pokemon.HistoryEntry, len(c.History))
+		for j, h := range c.History {
+			history[j] = pokemon.HistoryEntry{Time: h.Time.UTC(), Event: h.Event}
+		}
+		c.History = history
+		caught[i] = c
+	}
+	return caught
+}
@@ -0,0 +1,108 @@
+package sqlitestore
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// migrations upgrade the schema one version at a time. The schema version
+// is kept in PRAGMA user_version; append new migrations, never edit old ones.
+var migrations = []string{
+	// 1: caught Pokemon, the catch log and the exploration log
+	`
+	CREATE TABLE caught_pokemon (
+		id              INTEGER PRIMARY KEY,
+		nickname        TEXT    NOT NULL DEFAULT '',
+		caught_at       INTEGER NOT NULL,
+		location        TEXT    NOT NULL DEFAULT '',
+		name            TEXT    NOT NULL,
+		species_name    TEXT    NOT NULL DEFAULT '',
+		height          INTEGER NOT NULL,
+		weight          INTEGER NOT NULL,
+		base_experience INTEGER NOT NULL,
+		level           INTEGER NOT NULL,
+		friendship      INTEGER NOT NULL,
+		held_item       TEXT    NOT NULL DEFAULT '',
+		types           TEXT    NOT NULL,
+		stats           TEXT    NOT NULL,
+		abilities       TEXT    NOT NULL,
+		species         TEXT,
+		history         TEXT    NOT NULL
+	);
+	CREATE INDEX caught_pokemon_caught_at ON caught_pokemon (caught_at);
+
+	CREATE TABLE catch_attempts (
+		id        INTEGER PRIMARY KEY AUTOINCREMENT,
+		time      INTEGER NOT NULL,
+		pokemon   TEXT    NOT NULL,
+		location  TEXT    NOT NULL DEFAULT '',
+		rate      REAL    NOT NULL,
+		caught    INTEGER NOT NULL,
+		caught_id INTEGER
+	);
+	CREATE INDEX catch_attempts_time ON catch_attempts (time);
+
+	CREATE TABLE explorations (
+		id      INTEGER PRIMARY KEY AUTOINCREMENT,
+		time    INTEGER NOT NULL,
+		area    TEXT    NOT NULL,
+		pokemon TEXT    NOT NULL
+	);
+	CREATE INDEX explorations_time ON explorations (time);
+
+	CREATE TABLE meta (
+		key   TEXT PRIMARY KEY,
+		value TEXT NOT NULL
+	);
+	`,
+	// 2: Pokemon the trainer has seen, backfilled from the activity log
+	`
+	CREATE TABLE seen_pokemon (
+		name       TEXT    PRIMARY KEY,
+		first_seen INTEGER NOT NULL
+	);
+
+	INSERT INTO seen_pokemon (name, first_seen)
+	SELECT name, MIN(time) FROM (
+		SELECT j.value AS name, e.time AS time FROM explorations e, json_each(e.pokemon) j
+		UNION ALL
+		SELECT pokemon, time FROM catch_attempts
+		UNION ALL
+		SELECT name, caught_at FROM caught_pokemon
+	)
+	GROUP BY name;
+	`,
+}
+
+// migrate brings the schema up to date, applying each pending migration in
+// its own transaction
+func migrate(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("database schema version %d is newer than this build supports (%d)", version, len(migrations))
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d failed: %w", i+1, err)
+		}
+		// PRAGMA doesn't take bound parameters
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d failed: %w", i+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("migration %d failed: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
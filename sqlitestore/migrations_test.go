@@ -0,0 +1,102 @@
+package sqlitestore
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var testStart = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestMigrateBackfillsSightings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "x.db")
+	at := func(hours int) int64 { return testStart.Add(time.Duration(hours) * time.Hour).UnixMilli() }
+
+	// A database written by a build that only knew the first migration
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{
+		migrations[0],
+		"PRAGMA user_version = 1",
+		fmt.Sprintf(`INSERT INTO explorations (time, area, pokemon) VALUES
+			(%d, 'route-1', '["pidgey","rattata"]'),
+			(%d, 'route-2', '["pidgey","caterpie"]'),
+			(%d, 'route-3', '[]')`, at(2), at(1), at(0)),
+		fmt.Sprintf(`INSERT INTO catch_attempts (time, pokemon, rate, caught) VALUES
+			(%d, 'rattata', 0.5, 0),
+			(%d, 'mewtwo', 0.1, 0)`, at(0), at(3)),
+		fmt.Sprintf(`INSERT INTO caught_pokemon
+			(id, caught_at, name, height, weight, base_experience, level, friendship, types, stats, abilities, history)
+			VALUES (1, %d, 'pikachu', 4, 60, 112, 5, 70, '[]', '{}', '[]', '[]')`, at(4)),
+		"INSERT INTO meta (key, value) VALUES ('next_id', '2')",
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db.Close()
+
+	repo, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer repo.Close()
+
+	var version int
+	if err := repo.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		t.Fatal(err)
+	}
+	if version != len(migrations) {
+		t.Errorf("user_version = %d, want %d", version, len(migrations))
+	}
+
+	pd, err := repo.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := pd.Count(); got != 1 {
+		t.Errorf("Count() = %d, want the caught Pokemon kept", got)
+	}
+	if got := pd.NextID(); got != 2 {
+		t.Errorf("NextID() = %d, want 2", got)
+	}
+
+	// Each Pokemon is first seen at the earliest exploration, attempt or catch
+	want := map[string]int64{
+		"pidgey":   at(1),
+		"rattata":  at(0),
+		"caterpie": at(1),
+		"mewtwo":   at(3),
+		"pikachu":  at(4),
+	}
+	sightings := pd.Sightings()
+	if len(sightings) != len(want) {
+		t.Errorf("Sightings() = %v, want %d Pokemon", sightings, len(want))
+	}
+	for _, s := range sightings {
+		if got := s.FirstSeen.UnixMilli(); got != want[s.Name] {
+			t.Errorf("%s first seen at %d, want %d", s.Name, got, want[s.Name])
+		}
+	}
+}
+
+func TestOpenRejectsNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "x.db")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d", len(migrations)+1)); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	if repo, err := Open(path); err == nil {
+		repo.Close()
+		t.Error("Open() error = nil, want an error for a newer schema")
+	}
+}
@@ -0,0 +1,354 @@
+package sqlitestore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mcoluomo/pokedexcli/pokemon"
+
+	_ "modernc.org/sqlite" // pure-Go driver, registers "sqlite"
+)
+
+// Repository is a pokemon.PokedexRepository backed by a SQLite database.
+// Times are stored as Unix milliseconds so they sort and compare correctly.
+type Repository struct {
+	db *sql.DB
+}
+
+// Open opens or creates the database at path and migrates it to the
+// current schema
+func Open(path string) (*Repository, error) {
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	// SQLite allows one writer; a single connection avoids "database is locked"
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to prepare %s: %w", path, err)
+	}
+
+	return &Repository{db: db}, nil
+}
+
+// Load reads every caught Pokemon back into a Pokedex
+func (r *Repository) Load() (*pokemon.Pokedex, error) {
+	rows, err := r.db.Query(`
+		SELECT id, nickname, caught_at, location, name, species_name, height, weight,
+		       base_experience, level, friendship, held_item, types, stats, abilities,
+		       species, history
+		FROM caught_pokemon ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Pokedex: %w", err)
+	}
+	defer rows.Close()
+
+	var caught []pokemon.CaughtPokemon
+	for rows.Next() {
+		c, err := scanCaught(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Pokedex: %w", err)
+		}
+		caught = append(caught, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load Pokedex: %w", err)
+	}
+
+	seen, err := r.sightings()
+	if err != nil {
+		return nil, err
+	}
+
+	nextID, err := r.nextID()
+	if err != nil {
+		return nil, err
+	}
+
+	return pokemon.RestorePokedex(caught, seen, nextID)
+}
+
+// Save replaces the stored Pokemon and sightings with the contents of pd in
+// one transaction
+func (r *Repository) Save(pd *pokemon.Pokedex) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to save Pokedex: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM caught_pokemon"); err != nil {
+		return fmt.Errorf("failed to save Pokedex: %w", err)
+	}
+
+	for _, c := range pd.All() {
+		if err := insertCaught(tx, c); err != nil {
+			return fmt.Errorf("failed to save #%d %s: %w", c.ID, c.Name, err)
+		}
+	}
+
+	if _, err := tx.Exec("DELETE FROM seen_pokemon"); err != nil {
+		return fmt.Errorf("failed to save Pokedex: %w", err)
+	}
+	for _, s := range pd.Sightings() {
+		if _, err := tx.Exec("INSERT INTO seen_pokemon (name, first_seen) VALUES (?, ?)",
+			s.Name, s.FirstSeen.UnixMilli()); err != nil {
+			return fmt.Errorf("failed to save sighting of %s: %w", s.Name, err)
+		}
+	}
+
+	if _, err := tx.Exec(`
+		INSERT INTO meta (key, value) VALUES ('next_id', ?)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value`,
+		strconv.Itoa(pd.NextID())); err != nil {
+		return fmt.Errorf("failed to save Pokedex: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save Pokedex: %w", err)
+	}
+	return nil
+}
+
+// RecordCatchAttempt appends to the catch log
+func (r *Repository) RecordCatchAttempt(a pokemon.CatchAttempt) error {
+	var caughtID sql.NullInt64
+	if a.Caught {
+		caughtID = sql.NullInt64{Int64: int64(a.CaughtID), Valid: true}
+	}
+
+	_, err := r.db.Exec(`
+		INSERT INTO catch_attempts (time, pokemon, location, rate, caught, caught_id)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		a.Time.UnixMilli(), a.Pokemon, a.Location, a.Rate, a.Caught, caughtID)
+	if err != nil {
+		return fmt.Errorf("failed to record catch attempt: %w", err)
+	}
+	return nil
+}
+
+// RecordExploration appends to the exploration log
+func (r *Repository) RecordExploration(e pokemon.Exploration) error {
+	found, err := json.Marshal(e.Pokemon)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(`INSERT INTO explorations (time, area, pokemon) VALUES (?, ?, ?)`,
+		e.Time.UnixMilli(), e.Area, string(found))
+	if err != nil {
+		return fmt.Errorf("failed to record exploration: %w", err)
+	}
+	return nil
+}
+
+// CatchAttempts returns attempts made at or after since, oldest first
+func (r *Repository) CatchAttempts(since time.Time) ([]pokemon.CatchAttempt, error) {
+	rows, err := r.db.Query(`
+		SELECT time, pokemon, location, rate, caught, caught_id
+		FROM catch_attempts WHERE time >= ? ORDER BY time, id`,
+		sinceMillis(since))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catch log: %w", err)
+	}
+	defer rows.Close()
+
+	var attempts []pokemon.CatchAttempt
+	for rows.Next() {
+		var (
+			a        pokemon.CatchAttempt
+			at       int64
+			caughtID sql.NullInt64
+		)
+		if err := rows.Scan(&at, &a.Pokemon, &a.Location, &a.Rate, &a.Caught, &caughtID); err != nil {
+			return nil, fmt.Errorf("failed to read catch log: %w", err)
+		}
+		a.Time = time.UnixMilli(at)
+		a.CaughtID = int(caughtID.Int64)
+		attempts = append(attempts, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read catch log: %w", err)
+	}
+	return attempts, nil
+}
+
+// Explorations returns explorations made at or after since, oldest first
+func (r *Repository) Explorations(since time.Time) ([]pokemon.Exploration, error) {
+	rows, err := r.db.Query(`
+		SELECT time, area, pokemon FROM explorations
+		WHERE time >= ? ORDER BY time, id`,
+		sinceMillis(since))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exploration log: %w", err)
+	}
+	defer rows.Close()
+
+	var explorations []pokemon.Exploration
+	for rows.Next() {
+		var (
+			e     pokemon.Exploration
+			at    int64
+			found string
+		)
+		if err := rows.Scan(&at, &e.Area, &found); err != nil {
+			return nil, fmt.Errorf("failed to read exploration log: %w", err)
+		}
+		if err := json.Unmarshal([]byte(found), &e.Pokemon); err != nil {
+			return nil, fmt.Errorf("failed to read exploration log: %w", err)
+		}
+		e.Time = time.UnixMilli(at)
+		explorations = append(explorations, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read exploration log: %w", err)
+	}
+	return explorations, nil
+}
+
+// Close closes the database
+func (r *Repository) Close() error {
+	return r.db.Close()
+}
+
+// sinceMillis converts a lower bound to Unix milliseconds, rounding up so
+// entries stored for earlier in the same millisecond are left out
+func sinceMillis(since time.Time) int64 {
+	ms := since.UnixMilli()
+	if time.UnixMilli(ms).Before(since) {
+		ms++
+	}
+	return ms
+}
+
+// sightings reads every seen Pokemon
+func (r *Repository) sightings() ([]pokemon.Sighting, error) {
+	rows, err := r.db.Query("SELECT name, first_seen FROM seen_pokemon ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Pokedex: %w", err)
+	}
+	defer rows.Close()
+
+	var seen []pokemon.Sighting
+	for rows.Next() {
+		var (
+			s  pokemon.Sighting
+			at int64
+		)
+		if err := rows.Scan(&s.Name, &at); err != nil {
+			return nil, fmt.Errorf("failed to load Pokedex: %w", err)
+		}
+		s.FirstSeen = time.UnixMilli(at)
+		seen = append(seen, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to load Pokedex: %w", err)
+	}
+	return seen, nil
+}
+
+// nextID reads the stored next ID, defaulting to 1 for a new database
+func (r *Repository) nextID() (int, error) {
+	var value string
+	err := r.db.QueryRow("SELECT value FROM meta WHERE key = 'next_id'").Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 1, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to load Pokedex: %w", err)
+	}
+
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid next_id %q: %w", value, err)
+	}
+	return id, nil
+}
+
+// insertCaught writes one caught Pokemon. List-like fields are stored as JSON.
+func insertCaught(tx *sql.Tx, c pokemon.CaughtPokemon) error {
+	types, err := json.Marshal(c.Types)
+	if err != nil {
+		return err
+	}
+	stats, err := json.Marshal(c.Stats)
+	if err != nil {
+		return err
+	}
+	abilities, err := json.Marshal(c.Abilities)
+	if err != nil {
+		return err
+	}
+	history, err := json.Marshal(c.History)
+	if err != nil {
+		return err
+	}
+
+	var species sql.NullString
+	if c.Species != nil {
+		data, err := json.Marshal(c.Species)
+		if err != nil {
+			return err
+		}
+		species = sql.NullString{String: string(data), Valid: true}
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO caught_pokemon (id, nickname, caught_at, location, name, species_name,
+			height, weight, base_experience, level, friendship, held_item, types, stats,
+			abilities, species, history)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		c.ID, c.Nickname, c.CaughtAt.UnixMilli(), c.Location, c.Name, c.SpeciesName,
+		c.Height, c.Weight, c.BaseExperience, c.Level, c.Friendship, c.HeldItem,
+		string(types), string(stats), string(abilities), species, string(history))
+	return err
+}
+
+// scanCaught reads one row of caught_pokemon
+func scanCaught(rows *sql.Rows) (pokemon.CaughtPokemon, error) {
+	var (
+		c                                pokemon.CaughtPokemon
+		caughtAt                         int64
+		types, stats, abilities, history string
+		species                          sql.NullString
+	)
+	err := rows.Scan(&c.ID, &c.Nickname, &caughtAt, &c.Location, &c.Name, &c.SpeciesName,
+		&c.Height, &c.Weight, &c.BaseExperience, &c.Level, &c.Friendship, &c.HeldItem,
+		&types, &stats, &abilities, &species, &history)
+	if err != nil {
+		return pokemon.CaughtPokemon{}, err
+	}
+	c.CaughtAt = time.UnixMilli(caughtAt)
+
+	for _, f := range []struct {
+		data string
+		dst  any
+	}{
+		{types, &c.Types},
+		{stats, &c.Stats},
+		{abilities, &c.Abilities},
+		{history, &c.History},
+	} {
+		if err := json.Unmarshal([]byte(f.data), f.dst); err != nil {
+			return pokemon.CaughtPokemon{}, fmt.Errorf("Pokemon #%d is corrupt: %w", c.ID, err)
+		}
+	}
+	if species.Valid {
+		if err := json.Unmarshal([]byte(species.String), &c.Species); err != nil {
+			return pokemon.CaughtPokemon{}, fmt.Errorf("Pokemon #%d is corrupt: %w", c.ID, err)
+		}
+	}
+	if c.Stats == nil {
+		c.Stats = make(map[string]int)
+	}
+
+	return c, nil
+}
+
+var _ pokemon.PokedexRepository = (*Repository)(nil)
@@ -0,0 +1,126 @@
+package sqlitestore
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/mcoluomo/pokedexcli/pokemon"
+	"github.com/mcoluomo/pokedexcli/repotest"
+)
+
+func TestRepository(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) pokemon.PokedexRepository {
+		return openTemp(t)
+	})
+}
+
+// openTemp opens a new database in a temporary directory, closing it when
+// the test ends
+func openTemp(t *testing.T) *Repository {
+	t.Helper()
+	repo, err := Open(filepath.Join(t.TempDir(), "x.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+	return repo
+}
+
+func TestRepositoryPersistsAcrossOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "x.db")
+	repo, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pd := pokemon.NewPokedex()
+	pd.Catch(pokemon.CaughtPokemon{Pokemon: pokemon.Pokemon{Name: "pikachu"}, CaughtAt: testStart})
+	if err := repo.Save(pd); err != nil {
+		t.Fatal(err)
+	}
+	repo.Close()
+
+	reopened, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() again error = %v", err)
+	}
+	defer reopened.Close()
+	loaded, err := reopened.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Count(); got != 1 {
+		t.Errorf("Count() after reopening = %d, want 1", got)
+	}
+}
+
+func TestRepositoryColumns(t *testing.T) {
+	repo := openTemp(t)
+
+	pd := pokemon.NewPokedex()
+	pd.Catch(pokemon.CaughtPokemon{
+		Pokemon: pokemon.Pokemon{
+			Name:    "pikachu",
+			Types:   []string{"electric"},
+			Stats:   map[string]int{"speed": 90},
+			History: []pokemon.HistoryEntry{{Time: testStart, Event: "was caught"}},
+		},
+		CaughtAt: testStart,
+	})
+	pd.Catch(pokemon.CaughtPokemon{Pokemon: pokemon.Pokemon{Name: "pidgey"}, CaughtAt: testStart})
+	if err := repo.Save(pd); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.RecordExploration(pokemon.Exploration{Time: testStart, Area: "route-1", Pokemon: []string{"pidgey"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		caughtAt     int64
+		types, stats string
+		species      sql.NullString
+	)
+	err := repo.db.QueryRow("SELECT caught_at, types, stats, species FROM caught_pokemon WHERE id = 1").
+		Scan(&caughtAt, &types, &stats, &species)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caughtAt != testStart.UnixMilli() {
+		t.Errorf("caught_at = %d, want %d", caughtAt, testStart.UnixMilli())
+	}
+	if types != `["electric"]` {
+		t.Errorf("types = %s, want a JSON array", types)
+	}
+	if stats != `{"speed":90}` {
+		t.Errorf("stats = %s, want a JSON object", stats)
+	}
+	if species.Valid {
+		t.Errorf("species = %s, want NULL when there is no species data", species.String)
+	}
+
+	var found string
+	if err := repo.db.QueryRow("SELECT pokemon FROM explorations").Scan(&found); err != nil {
+		t.Fatal(err)
+	}
+	if found != `["pidgey"]` {
+		t.Errorf("explorations.pokemon = %s, want a JSON array", found)
+	}
+
+	var nextID string
+	if err := repo.db.QueryRow("SELECT value FROM meta WHERE key = 'next_id'").Scan(&nextID); err != nil {
+		t.Fatal(err)
+	}
+	if nextID != "3" {
+		t.Errorf("next_id = %s, want 3", nextID)
+	}
+}
+
+func TestRepositoryRejectsCorruptNextID(t *testing.T) {
+	repo := openTemp(t)
+	if _, err := repo.db.Exec("INSERT INTO meta (key, value) VALUES ('next_id', 'seven')"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Load(); err == nil {
+		t.Error("Load() error = nil, want an error for a bad next_id")
+	}
+}